@@ -2,53 +2,205 @@ package main
 
 import (
 	"context"
-	"errors"
+	"crypto/rand"
+	"crypto/tls"
+	"flag"
 	"fmt"
-	"net/http"
+	"log/slog"
+	"net"
+	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 
 	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/grpcapi"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/metrics"
 	postgresRepository "github.com/raphael-foliveira/go-table-tests/internal/adapters/repository/postgres"
 	"github.com/raphael-foliveira/go-table-tests/internal/adapters/security"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/tracing"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
 	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
 	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/config"
 	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/database"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/lifecycle"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
 	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/server"
+	usersv1 "github.com/raphael-foliveira/go-table-tests/pkg/proto/users/v1"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
+func loadConfig(errorHandling flag.ErrorHandling) (*config.Config, bool, error) {
+	flags := flag.NewFlagSet("app", errorHandling)
+	printConfig := flags.Bool("print-config", false, "print the configuration, with secrets redacted, and exit")
+	cfg, err := config.Load(flags, os.Args[1:], os.LookupEnv)
+	return cfg, *printConfig, err
+}
+
+func emailPolicy(cfg *config.Config) domain.EmailPolicy {
+	return domain.EmailPolicy{
+		DisposableDomains:   cfg.Email.DisposableDomains,
+		CanonicalizeAliases: cfg.Email.CanonicalizeAliases,
+	}
+}
+
 func main() {
-	cfg, err := config.NewConfig(".env")
+	err := run()
+	if err != nil {
+		slog.Error("server stopped", "error", err)
+	}
+	os.Exit(lifecycle.ExitCode(err))
+}
+
+// run sets the application up and runs it until it is signalled to stop.
+// Setup errors are returned like the errors of the manager, so that main
+// maps both to an exit code.
+func run() error {
+	err := config.LoadDotenv(".env")
+	if err != nil {
+		return err
+	}
+
+	cfg, printConfig, err := loadConfig(flag.ExitOnError)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	app := server.NewServer()
+	if printConfig {
+		return cfg.Print(os.Stdout)
+	}
+
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(cfg.Log.Level)
+	logger := logging.New(os.Stdout, cfg.Log.Format, logLevel)
+	slog.SetDefault(logger)
+
+	manager := lifecycle.New(logger, cfg.Server.ShutdownTimeout, cfg.Server.DrainDelay)
 
-	apiGroup := app.Group("/api")
+	domain.SetEmailPolicy(emailPolicy(cfg))
 
-	db, err := database.New(cfg.DatabaseURL)
+	reloader := config.NewReloader(cfg, func() (*config.Config, error) {
+		cfg, _, err := loadConfig(flag.ContinueOnError)
+		return cfg, err
+	}, logger)
+	reloader.Subscribe(func(cfg *config.Config) {
+		logLevel.Set(cfg.Log.Level)
+		domain.SetEmailPolicy(emailPolicy(cfg))
+	})
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	manager.Add("config", func() error { return reloader.Watch(watchCtx, hangup) }, func(context.Context) error {
+		stopWatching()
+		return nil
+	})
+
+	tracerProvider, err := tracing.NewProvider(context.Background(), cfg.Trace.Exporter, os.Stdout)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("tracing: %w", err)
 	}
+	manager.Add("tracing", nil, tracerProvider.Shutdown)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	tracer := tracing.NewOTel(tracerProvider)
 
-	usersRepository := postgresRepository.NewUsers(db)
-	hasher := security.NewHashingService()
-	usersService := service.NewUsersService(usersRepository, hasher)
-	usersHandler := api.NewUsersHandler(usersService)
+	securityOptions := cfg.SecurityOptions()
+	securityOptions.Overrides = map[string]server.RouteSecurity{
+		api.APIBaseURL + "/docs": {ContentSecurityPolicy: api.DocsContentSecurityPolicy},
+	}
+	app := server.NewServer(logger, securityOptions)
+	app.Use(tracing.Middleware(tracerProvider, otel.GetTextMapPropagator()))
+	if cfg.TLS.ClientCAFile != "" {
+		app.Use(server.ClientCertificatePrincipal(cfg.TLS.AdminClients))
+	}
 
-	usersHandler.SetupRoutes(apiGroup)
+	db, err := database.New(cfg.Database.URL.Value())
+	if err != nil {
+		return fmt.Errorf("database: %w", err)
+	}
+	database.ConfigurePool(db, database.Pool{
+		MaxOpenConns:    cfg.Database.MaxOpenConns,
+		MaxIdleConns:    cfg.Database.MaxIdleConns,
+		ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+		ConnMaxIdleTime: cfg.Database.ConnMaxIdleTime,
+	})
 
-	ctx, done := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
-	defer done()
+	manager.Add("database", nil, func(context.Context) error { return db.Close() })
+
+	err = database.Migrate(db)
+	if err != nil {
+		return fmt.Errorf("migrations: %w", err)
+	}
 
-	go func() {
-		err := app.Start(3000)
-		if err != nil && !errors.Is(err, http.ErrServerClosed) {
-			panic(err)
+	health := server.NewHealth(cfg.Server.ReadinessTimeout, logger)
+	health.Register(
+		server.NewChecker("database", db.PingContext),
+		server.NewChecker("migrations", func(ctx context.Context) error {
+			return database.CheckMigrations(ctx, db)
+		}),
+	)
+	manager.OnDrain(health.Drain)
+
+	promMetrics := metrics.NewPrometheus()
+	promMetrics.RegisterDB(db.DB, "users")
+	app.Use(promMetrics.Middleware())
+
+	usersRepository := postgresRepository.NewUsers(db, tracer)
+	hasher := service.NewTimedHasher(security.NewHashingService(cfg.Hashing.BcryptCost), promMetrics)
+	unitOfWork := postgresRepository.NewUnitOfWork(db, postgresRepository.DefaultMaxAttempts, tracer)
+	usersService := service.NewUsersService(usersRepository, hasher, unitOfWork, logger, promMetrics, tracer)
+	signingKey := []byte(cfg.Auth.SigningKey.Value())
+	if len(signingKey) == 0 {
+		signingKey = make([]byte, security.MinSigningKeyLength)
+		if _, err := rand.Read(signingKey); err != nil {
+			return fmt.Errorf("signing key: %w", err)
 		}
-	}()
-	<-ctx.Done()
+		logger.Warn("auth.signing_key is not set, using a random key: sessions end when the server restarts")
+	}
+	tokens, err := security.NewTokens(signingKey, cfg.Auth.AccessTokenTTL)
+	if err != nil {
+		return fmt.Errorf("tokens: %w", err)
+	}
+	reloader.Subscribe(func(cfg *config.Config) {
+		tokens.SetTTL(cfg.Auth.AccessTokenTTL)
+	})
+	sessions := api.NewSessions(tokens, cfg.SessionOptions())
+	setupRoutes(app, health, usersService, tokens, sessions, logger)
 
-	fmt.Println("server interrupted")
+	if cfg.Metrics.Address != "" {
+		metricsApp := server.NewServer(logger, securityOptions)
+		promMetrics.SetupRoutes(metricsApp.Echo)
+		manager.Add("metrics", func() error { return metricsApp.Start(cfg.Metrics.Address, nil) }, metricsApp.Shutdown)
+	}
+
+	var tlsConfig *tls.Config
+	if cfg.TLS.CertFile != "" {
+		serverTLS, err := server.NewTLS(cfg.TLSOptions(), logger)
+		if err != nil {
+			return fmt.Errorf("tls: %w", err)
+		}
+		tlsConfig = serverTLS.Config()
+		certificatesCtx, stopWatchingCertificates := context.WithCancel(context.Background())
+		manager.Add("certificates", func() error { return serverTLS.Watch(certificatesCtx) }, func(context.Context) error {
+			stopWatchingCertificates()
+			return nil
+		})
+	}
+	address := net.JoinHostPort(cfg.Server.Host, strconv.Itoa(cfg.Server.Port))
+	manager.Add("http", func() error { return app.Start(address, tlsConfig) }, app.Shutdown)
+
+	// Validation guarantees TLS is configured whenever gRPC is enabled, so
+	// that API keys never travel in plaintext.
+	if cfg.GRPC.Port != 0 {
+		grpcServer := server.NewGRPCServer(logger, config.Values(cfg.GRPC.APIKeys), grpc.Creds(credentials.NewTLS(tlsConfig)))
+		usersv1.RegisterUsersServiceServer(grpcServer, grpcapi.NewUsersServer(usersService))
+		grpcAddress := net.JoinHostPort(cfg.Server.Host, strconv.Itoa(cfg.GRPC.Port))
+		manager.Add("grpc", func() error { return grpcServer.Start(grpcAddress) }, grpcServer.Shutdown)
+	}
+
+	ctx, done := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer done()
+	return manager.Run(ctx)
 }
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/gqlapi"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/server"
+)
+
+// setupRoutes registers every route the public HTTP server serves. The REST
+// routes under api.APIBaseURL are described by the OpenAPI document; the
+// probes, GraphQL endpoint and documentation assets are not. Metrics are
+// served on a listener of their own.
+func setupRoutes(
+	app *server.Server,
+	health *server.Health,
+	usersService ports.UsersService,
+	tokens ports.TokenService,
+	sessions *api.Sessions,
+	logger *slog.Logger,
+) {
+	health.SetupRoutes(app.Echo)
+
+	usersHandler := api.NewUsersHandler(usersService, sessions)
+	docsHandler := api.NewDocsHandler(usersHandler)
+
+	apiGroup := app.Group(api.APIBaseURL)
+	apiGroup.Use(sessions.Authenticate())
+	apiGroup.Use(api.ValidateRequests(docsHandler.Document(), api.APIBaseURL))
+
+	usersHandler.SetupRoutes(apiGroup)
+	docsHandler.SetupRoutes(apiGroup)
+	gqlapi.NewHandler(usersService, tokens, gqlapi.DefaultLimits, logger).SetupRoutes(apiGroup)
+}
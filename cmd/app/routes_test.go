@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api/openapi"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/gqlapi"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/metrics"
+	memoryRepository "github.com/raphael-foliveira/go-table-tests/internal/adapters/repository/memory"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/security"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/tracing"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// setUpApp wires an HTTP server the way main does, over the memory
+// repository.
+func setUpApp(t *testing.T) *server.Server {
+	logger := logging.Discard()
+	app := server.NewServer(logger, server.DefaultSecurityOptions())
+
+	users := memoryRepository.NewUsers()
+	usersService := service.NewUsersService(users, security.NewHashingService(bcrypt.MinCost),
+		memoryRepository.NewUnitOfWork(users), logger, metrics.Discard(), tracing.Discard())
+	tokens, err := security.NewTokens([]byte(strings.Repeat("k", security.MinSigningKeyLength)), time.Minute)
+	require.NoError(t, err)
+
+	setupRoutes(app, server.NewHealth(server.DefaultCheckTimeout, logger), usersService, tokens,
+		api.NewSessions(tokens, api.DefaultSessionOptions()), logger)
+	return app
+}
+
+// fetchDocument returns the OpenAPI document as app serves it.
+func fetchDocument(t *testing.T, app *server.Server) *openapi.Document {
+	recorder := httptest.NewRecorder()
+	app.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, api.APIBaseURL+"/openapi.json", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var document *openapi.Document
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&document))
+	return document
+}
+
+func TestSetupRoutes_Documented(t *testing.T) {
+	app := setUpApp(t)
+	document := fetchDocument(t, app)
+
+	// Routes outside the REST API, which the document does not describe.
+	undocumented := map[string]bool{
+		http.MethodGet + " " + server.LivenessPath:                 true,
+		http.MethodGet + " " + server.ReadinessPath:                true,
+		http.MethodGet + " " + api.APIBaseURL + gqlapi.Path:        true,
+		http.MethodPost + " " + api.APIBaseURL + gqlapi.Path:       true,
+		http.MethodGet + " " + api.APIBaseURL + api.DocsAssetsPath: true,
+	}
+
+	registered := map[string]bool{}
+	for _, route := range app.Routes() {
+		if route.Method != echo.RouteNotFound {
+			registered[route.Method+" "+route.Path] = true
+		}
+	}
+
+	expected := map[string]bool{}
+	for route := range undocumented {
+		expected[route] = true
+	}
+	for path, pathItem := range document.Paths {
+		for method := range pathItem.Operations() {
+			route := method + " " + api.APIBaseURL + path
+			assert.False(t, undocumented[route], "%s is documented", route)
+			expected[route] = true
+		}
+	}
+
+	assert.Equal(t, expected, registered)
+}
+
+func TestSetupRoutes_Responses(t *testing.T) {
+	app := setUpApp(t)
+	document := fetchDocument(t, app)
+
+	// The steps share the server and run in order: login needs the account
+	// created by signup.
+	steps := []struct {
+		headers            map[string]string
+		name               string
+		method             string
+		path               string
+		body               string
+		expectedStatusCode int
+	}{
+		{
+			name:               "Signup",
+			method:             http.MethodPost,
+			path:               "/users/signup",
+			body:               `{"email":"alice@example.com","username":"alice","password":"correct-horse"}`,
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "Signup conflict",
+			method:             http.MethodPost,
+			path:               "/users/signup",
+			body:               `{"email":"alice@example.com","username":"alice","password":"correct-horse"}`,
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:               "Signup validation error",
+			method:             http.MethodPost,
+			path:               "/users/signup",
+			body:               `{"email":"alice","username":"al","password":"short"}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Signup validation error as plain JSON",
+			method:             http.MethodPost,
+			path:               "/users/signup",
+			body:               `{"email":"alice","username":"al","password":"short"}`,
+			headers:            map[string]string{echo.HeaderAccept: echo.MIMEApplicationJSON},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Bearer login",
+			method:             http.MethodPost,
+			path:               "/users/login",
+			body:               `{"email":"alice@example.com","password":"correct-horse"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Cookie login",
+			method:             http.MethodPost,
+			path:               "/users/login",
+			body:               `{"email":"alice@example.com","password":"correct-horse","session":"cookie"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Login with a wrong password",
+			method:             http.MethodPost,
+			path:               "/users/login",
+			body:               `{"email":"alice@example.com","password":"wrong-horse"}`,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Logout",
+			method:             http.MethodPost,
+			path:               "/users/logout",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:               "Documentation page",
+			method:             http.MethodGet,
+			path:               "/docs",
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, step := range steps {
+		req := httptest.NewRequest(step.method, api.APIBaseURL+step.path, strings.NewReader(step.body))
+		if step.body != "" {
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		}
+		for key, value := range step.headers {
+			req.Header.Set(key, value)
+		}
+		recorder := httptest.NewRecorder()
+		app.ServeHTTP(recorder, req)
+
+		require.Equal(t, step.expectedStatusCode, recorder.Code, step.name)
+
+		var mediaType string
+		if contentType := recorder.Header().Get(echo.HeaderContentType); contentType != "" {
+			var err error
+			mediaType, _, err = mime.ParseMediaType(contentType)
+			require.NoError(t, err, step.name)
+		}
+		schema, ok := document.ResponseSchema(step.method, step.path, recorder.Code, mediaType)
+		require.True(t, ok, "%s: %d %s is not documented", step.name, recorder.Code, mediaType)
+		if schema == nil {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(recorder.Body.Bytes()))
+		decoder.UseNumber()
+		var body any
+		require.NoError(t, decoder.Decode(&body), step.name)
+		assert.Empty(t, document.Validate(schema, body), step.name)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/secrets"
+)
+
+type cli struct {
+	readKey func() (*secrets.Key, error)
+	stdin   io.Reader
+	stdout  io.Writer
+	file    string
+}
+
+func (c *cli) run(args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	command, args := args[0], args[1:]
+	if command == "keygen" {
+		return c.keygen(args)
+	}
+
+	var name string
+	switch command {
+	case "list":
+		if len(args) != 0 {
+			return errUsage
+		}
+	case "get", "set", "delete":
+		if len(args) != 1 {
+			return fmt.Errorf("%w: %s takes a secret name", errUsage, command)
+		}
+		name = args[0]
+	default:
+		return fmt.Errorf("%w: unknown command %q", errUsage, command)
+	}
+
+	store, err := c.open()
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "list":
+		for _, name := range store.Names() {
+			fmt.Fprintln(c.stdout, name)
+		}
+		return nil
+	case "get":
+		value, err := store.Get(name)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(c.stdout, value)
+		return err
+	case "set":
+		value, err := io.ReadAll(c.stdin)
+		if err != nil {
+			return err
+		}
+		store.Set(name, strings.TrimRight(string(value), "\r\n"))
+		return store.Save()
+	default:
+		if err := store.Delete(name); err != nil {
+			return err
+		}
+		return store.Save()
+	}
+}
+
+func (c *cli) keygen(args []string) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+	key, err := secrets.GenerateKey()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(c.stdout, key)
+	return err
+}
+
+func (c *cli) open() (*secrets.Store, error) {
+	key, err := c.readKey()
+	if err != nil {
+		return nil, err
+	}
+	return secrets.Open(c.file, key)
+}
+
+var errUsage = errors.New("usage")
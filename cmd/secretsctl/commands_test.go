@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setUpCLI(t *testing.T, file string, key *secrets.Key, stdin string) (*cli, *bytes.Buffer) {
+	stdout := &bytes.Buffer{}
+	return &cli{
+		file:    file,
+		readKey: func() (*secrets.Key, error) { return key, nil },
+		stdin:   strings.NewReader(stdin),
+		stdout:  stdout,
+	}, stdout
+}
+
+func TestCLI_Commands(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "secrets.enc")
+	key, err := secrets.GenerateKey()
+	require.NoError(t, err)
+
+	steps := []struct {
+		name           string
+		stdin          string
+		expectedOutput string
+		args           []string
+	}{
+		{name: "Set database URL", args: []string{"set", "database_url"}, stdin: "postgres://localhost\n"},
+		{name: "Set signing key", args: []string{"set", "signing_key"}, stdin: "s3cr3t"},
+		{name: "List", args: []string{"list"}, expectedOutput: "database_url\nsigning_key\n"},
+		{name: "Get", args: []string{"get", "database_url"}, expectedOutput: "postgres://localhost\n"},
+		{name: "Delete", args: []string{"delete", "signing_key"}},
+		{name: "List after delete", args: []string{"list"}, expectedOutput: "database_url\n"},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			c, stdout := setUpCLI(t, file, key, step.stdin)
+
+			require.NoError(t, c.run(step.args))
+			assert.Equal(t, step.expectedOutput, stdout.String())
+		})
+	}
+}
+
+func TestCLI_Keygen(t *testing.T) {
+	c, stdout := setUpCLI(t, "", nil, "")
+
+	require.NoError(t, c.run([]string{"keygen"}))
+
+	_, err := secrets.ParseKey(stdout.String())
+	assert.NoError(t, err)
+}
+
+func TestCLI_Errors(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "secrets.enc")
+	key, err := secrets.GenerateKey()
+	require.NoError(t, err)
+
+	tests := []struct {
+		expectedError error
+		name          string
+		args          []string
+	}{
+		{name: "No command", expectedError: errUsage},
+		{name: "Unknown command", args: []string{"edit"}, expectedError: errUsage},
+		{name: "Missing name", args: []string{"get"}, expectedError: errUsage},
+		{name: "Unknown secret", args: []string{"get", "missing"}, expectedError: secrets.ErrSecretNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := setUpCLI(t, file, key, "")
+
+			assert.ErrorIs(t, c.run(tt.args), tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Command secretsctl edits the encrypted secrets store that configuration
+// values refer to with secret:// references.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/config"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/secrets"
+)
+
+const usage = `usage: secretsctl [-file FILE] <command> [args]
+
+The store key is read from SECRETS_KEY or from the file named by
+SECRETS_KEY_FILE.
+
+commands:
+  keygen         print a new store key
+  list           list the secret names
+  get NAME       print a secret
+  set NAME       store a secret read from stdin
+  delete NAME    remove a secret
+`
+
+func main() {
+	flags := flag.NewFlagSet("secretsctl", flag.ExitOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	defaultFile, ok := os.LookupEnv(secrets.FileVariable)
+	if !ok {
+		defaultFile = "secrets.enc"
+	}
+	file := flags.String("file", defaultFile, "encrypted secrets file")
+	flags.Parse(os.Args[1:])
+
+	c := &cli{
+		file: *file,
+		readKey: func() (*secrets.Key, error) {
+			return config.ReadSecretsKey(os.LookupEnv)
+		},
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+	}
+
+	err := c.run(flags.Args())
+	if errors.Is(err, errUsage) {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "secretsctl: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/bulk"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+)
+
+const (
+	formatCSV   = "csv"
+	formatJSONL = "jsonl"
+)
+
+func (c *cli) importUsers(ctx context.Context, args []string) error {
+	flags := c.newFlagSet("import")
+	formatFlag := flags.String("format", "", "input format: csv or jsonl (default: from the file extension)")
+	dryRun := flags.Bool("dry-run", false, "validate every row without creating any user")
+	continueOnError := flags.Bool("continue-on-error", false, "skip failing rows instead of stopping at the first one")
+	batchSize := flags.Int("batch-size", 0, "number of users created per transaction")
+	invites := flags.String("invites", "", "file to append users without a password hash to, for a mailer to invite")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 1 {
+		return errUsage
+	}
+
+	name := flags.Arg(0)
+	format, err := resolveFormat(*formatFlag, name)
+	if err != nil {
+		return err
+	}
+
+	input := c.stdin
+	if name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	var reader ports.UserRecordReader
+	if format == formatCSV {
+		reader, err = bulk.NewCSVReader(input)
+		if err != nil {
+			return err
+		}
+	} else {
+		reader = bulk.NewJSONLReader(input)
+	}
+
+	var inviter ports.Inviter
+	if *invites != "" {
+		file, err := os.OpenFile(*invites, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		inviter = bulk.NewListInviter(file)
+	}
+
+	report, err := c.newBulk(inviter).Import(ctx, reader, domain.ImportOptions{
+		BatchSize:       *batchSize,
+		DryRun:          *dryRun,
+		ContinueOnError: *continueOnError,
+	})
+	if report != nil {
+		if printErr := c.printReport(report, *dryRun); printErr != nil && err == nil {
+			err = printErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if report.Failed > 0 {
+		return errImportFailures
+	}
+	return nil
+}
+
+func (c *cli) exportUsers(ctx context.Context, args []string) error {
+	flags := c.newFlagSet("export")
+	formatFlag := flags.String("format", "", "output format: csv or jsonl (default: from the file extension, or jsonl)")
+	if err := flags.Parse(args); err != nil || flags.NArg() > 1 {
+		return errUsage
+	}
+
+	name := flags.Arg(0)
+	if name == "" {
+		name = "-"
+	}
+	if *formatFlag == "" && name == "-" {
+		*formatFlag = formatJSONL
+	}
+	format, err := resolveFormat(*formatFlag, name)
+	if err != nil {
+		return err
+	}
+
+	output := c.stdout
+	if name != "-" {
+		file, err := os.Create(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		output = file
+	}
+
+	var writer ports.UserRecordWriter = bulk.NewJSONLWriter(output)
+	if format == formatCSV {
+		writer = bulk.NewCSVWriter(output)
+	}
+
+	exported, err := c.newBulk(nil).Export(ctx, writer)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.stderr, "exported %d users\n", exported)
+	return nil
+}
+
+func resolveFormat(format, name string) (string, error) {
+	if format == "" {
+		switch filepath.Ext(name) {
+		case ".csv":
+			return formatCSV, nil
+		case ".jsonl", ".ndjson":
+			return formatJSONL, nil
+		}
+		return "", fmt.Errorf("%w: cannot infer the format of %q, pass -format", errUsage, name)
+	}
+	if format != formatCSV && format != formatJSONL {
+		return "", fmt.Errorf("%w: unknown format %q", errUsage, format)
+	}
+	return format, nil
+}
+
+type reportOutput struct {
+	Errors  []rowErrorOutput `json:"errors"`
+	Created int              `json:"created"`
+	Invited int              `json:"invited"`
+	Failed  int              `json:"failed"`
+	DryRun  bool             `json:"dry_run"`
+}
+
+type rowErrorOutput struct {
+	Error string `json:"error"`
+	Line  int    `json:"line"`
+}
+
+// printReport lists the failing rows by line, followed by the totals.
+func (c *cli) printReport(report *domain.ImportReport, dryRun bool) error {
+	rowErrs := slices.Clone(report.Errors)
+	slices.SortStableFunc(rowErrs, func(a, b *domain.ImportRowError) int {
+		return a.Line - b.Line
+	})
+
+	if c.output == outputJSON {
+		out := reportOutput{
+			Errors:  []rowErrorOutput{},
+			Created: report.Created,
+			Invited: report.Invited,
+			Failed:  report.Failed,
+			DryRun:  dryRun,
+		}
+		for _, rowErr := range rowErrs {
+			out.Errors = append(out.Errors, rowErrorOutput{Line: rowErr.Line, Error: rowErr.Err.Error()})
+		}
+		return json.NewEncoder(c.stdout).Encode(&out)
+	}
+
+	if len(rowErrs) > 0 {
+		writer := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "LINE\tERROR")
+		for _, rowErr := range rowErrs {
+			fmt.Fprintf(writer, "%d\t%v\n", rowErr.Line, rowErr.Err)
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+	}
+
+	prefix := ""
+	if dryRun {
+		prefix = "dry run: "
+	}
+	_, err := fmt.Fprintf(c.stdout, "%screated %d, invited %d, failed %d\n", prefix, report.Created, report.Invited, report.Failed)
+	return err
+}
+
+var errImportFailures = errors.New("some rows could not be imported")
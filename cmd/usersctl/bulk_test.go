@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
+	"github.com/raphael-foliveira/go-table-tests/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setUpBulkCLI(t *testing.T, output, stdin string) (*cli, *mocks.MockUsersBulkService, *bytes.Buffer) {
+	c, _, stdout := setUpCLI(t, output, stdin)
+	mockBulkService := mocks.NewMockUsersBulkService(t)
+	c.newBulk = func(inviter ports.Inviter) ports.UsersBulkService {
+		return mockBulkService
+	}
+	return c, mockBulkService, stdout
+}
+
+// readRecords drains reader, as the service would.
+func readRecords(t *testing.T, reader ports.UserRecordReader) []*domain.ImportRecord {
+	var records []*domain.ImportRecord
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			return records
+		}
+		records = append(records, record)
+	}
+}
+
+func TestCLI_Import(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.csv")
+	require.NoError(t, os.WriteFile(path, []byte("email,username\ntest@user.com,testuser\n"), 0o600))
+
+	tests := []struct {
+		expectedError  error
+		report         *domain.ImportReport
+		serviceErr     error
+		name           string
+		args           []string
+		stdin          string
+		output         string
+		expectedOutput string
+		expectedLines  int
+	}{
+		{
+			name:           "From a CSV file",
+			args:           []string{"import", "-dry-run", path},
+			output:         outputTable,
+			report:         &domain.ImportReport{Created: 1},
+			expectedOutput: "dry run: created 1, invited 0, failed 0\n",
+			expectedLines:  1,
+		},
+		{
+			name:   "From stdin with row errors",
+			args:   []string{"import", "-format", "jsonl", "-continue-on-error", "-"},
+			stdin:  "{\"email\":\"test@user.com\",\"username\":\"testuser\"}\n{\"email\":\"other@user.com\",\"username\":\"other\"}\n",
+			output: outputTable,
+			report: &domain.ImportReport{
+				Created: 1,
+				Failed:  2,
+				Errors: []*domain.ImportRowError{
+					{Line: 3, Err: service.ErrEmailAlreadyTaken},
+					{Line: 2, Err: service.ErrUsernameAlreadyTaken},
+				},
+			},
+			expectedOutput: "LINE  ERROR\n2     username already taken\n3     email already taken\ncreated 1, invited 0, failed 2\n",
+			expectedError:  errImportFailures,
+			expectedLines:  2,
+		},
+		{
+			name:   "Stopped import as JSON",
+			args:   []string{"import", path},
+			output: outputJSON,
+			report: &domain.ImportReport{
+				Failed: 1,
+				Errors: []*domain.ImportRowError{{Line: 2, Err: service.ErrEmailAlreadyTaken}},
+			},
+			serviceErr:     service.ErrImportStopped,
+			expectedOutput: `{"errors":[{"error":"email already taken","line":2}],"created":0,"invited":0,"failed":1,"dry_run":false}` + "\n",
+			expectedError:  service.ErrImportStopped,
+			expectedLines:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mockBulkService, stdout := setUpBulkCLI(t, tt.output, tt.stdin)
+
+			mockBulkService.EXPECT().
+				Import(mock.Anything, mock.Anything, mock.Anything).
+				RunAndReturn(func(ctx context.Context, reader ports.UserRecordReader, options domain.ImportOptions) (*domain.ImportReport, error) {
+					assert.Len(t, readRecords(t, reader), tt.expectedLines)
+					return tt.report, tt.serviceErr
+				})
+
+			err := c.run(context.Background(), tt.args)
+
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expectedOutput, stdout.String())
+		})
+	}
+}
+
+func TestCLI_ImportErrors(t *testing.T) {
+	tests := []struct {
+		expectedError error
+		name          string
+		args          []string
+	}{
+		{
+			name:          "Unknown extension",
+			args:          []string{"import", "users.txt"},
+			expectedError: errUsage,
+		},
+		{
+			name:          "Unknown format",
+			args:          []string{"import", "-format", "xml", "-"},
+			expectedError: errUsage,
+		},
+		{
+			name:          "Missing file",
+			args:          []string{"import"},
+			expectedError: errUsage,
+		},
+		{
+			name:          "Nonexistent file",
+			args:          []string{"import", filepath.Join(t.TempDir(), "missing.csv")},
+			expectedError: os.ErrNotExist,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _, _ := setUpBulkCLI(t, outputTable, "")
+			assert.ErrorIs(t, c.run(context.Background(), tt.args), tt.expectedError)
+		})
+	}
+}
+
+func TestCLI_Export(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.csv")
+	c, mockBulkService, _ := setUpBulkCLI(t, outputTable, "")
+
+	mockBulkService.EXPECT().
+		Export(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, writer ports.UserRecordWriter) (int, error) {
+			require.NoError(t, writer.Write(testProfile))
+			return 1, writer.Flush()
+		})
+
+	require.NoError(t, c.run(context.Background(), []string{"export", path}))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "id,email,username,role,disabled\n1,test@user.com,testuser,user,false\n", string(content))
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+)
+
+type cli struct {
+	users ports.UsersAdminService
+	// newBulk builds the import and export service; the inviter depends on
+	// the flags of the import command.
+	newBulk func(inviter ports.Inviter) ports.UsersBulkService
+	stdin   io.Reader
+	stdout  io.Writer
+	stderr  io.Writer
+	// readPassword prompts without echoing. It is nil when stdin is not a
+	// terminal, in which case passwords must come from -password-stdin.
+	readPassword func(prompt string) (string, error)
+	output       string
+}
+
+func (c *cli) run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	command, args := args[0], args[1:]
+	switch command {
+	case "create":
+		return c.create(ctx, args)
+	case "get":
+		return c.get(ctx, args)
+	case "list":
+		return c.list(ctx, args)
+	case "disable":
+		return c.disable(ctx, args)
+	case "reset-password":
+		return c.resetPassword(ctx, args)
+	case "set-role":
+		return c.setRole(ctx, args)
+	case "import":
+		return c.importUsers(ctx, args)
+	case "export":
+		return c.exportUsers(ctx, args)
+	}
+	return fmt.Errorf("%w: unknown command %q", errUsage, command)
+}
+
+func (c *cli) create(ctx context.Context, args []string) error {
+	flags := c.newFlagSet("create")
+	email := flags.String("email", "", "email of the new user")
+	username := flags.String("username", "", "username of the new user")
+	role := flags.String("role", string(domain.RoleUser), "role of the new user: user or admin")
+	passwordStdin := flags.Bool("password-stdin", false, "read the password from stdin instead of prompting")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 || *email == "" || *username == "" {
+		return errUsage
+	}
+
+	password, err := c.password(*passwordStdin)
+	if err != nil {
+		return err
+	}
+
+	profile, err := c.users.CreateUser(ctx, &domain.SignupPayload{
+		Email:    *email,
+		Username: *username,
+		Password: password,
+	}, domain.Role(*role))
+	if err != nil {
+		return err
+	}
+	return c.printUsers(profile)
+}
+
+func (c *cli) get(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	profile, err := c.users.GetUser(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return c.printUsers(profile)
+}
+
+func (c *cli) list(ctx context.Context, args []string) error {
+	flags := c.newFlagSet("list")
+	first := flags.Int("first", 0, "number of users per page")
+	after := flags.Uint("after", 0, "only list users with a greater ID")
+	all := flags.Bool("all", false, "follow every page")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		return errUsage
+	}
+
+	query := domain.ListUsersQuery{After: *after, First: *first}
+	var users []*domain.UserProfile
+	for {
+		page, err := c.users.List(ctx, query)
+		if err != nil {
+			return err
+		}
+		users = append(users, page.Users...)
+
+		if !page.HasNextPage || len(page.Users) == 0 {
+			break
+		}
+		query.After = page.Users[len(page.Users)-1].ID
+		if !*all {
+			fmt.Fprintf(c.stderr, "more users available: -after %d\n", query.After)
+			break
+		}
+	}
+	return c.printUsers(users...)
+}
+
+func (c *cli) disable(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	profile, err := c.users.DisableUser(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return c.printUsers(profile)
+}
+
+func (c *cli) resetPassword(ctx context.Context, args []string) error {
+	flags := c.newFlagSet("reset-password")
+	passwordStdin := flags.Bool("password-stdin", false, "read the password from stdin instead of prompting")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 1 {
+		return errUsage
+	}
+
+	password, err := c.password(*passwordStdin)
+	if err != nil {
+		return err
+	}
+
+	profile, err := c.users.ResetPassword(ctx, flags.Arg(0), password)
+	if err != nil {
+		return err
+	}
+	return c.printUsers(profile)
+}
+
+func (c *cli) setRole(ctx context.Context, args []string) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+
+	profile, err := c.users.SetRole(ctx, args[0], domain.Role(args[1]))
+	if err != nil {
+		return err
+	}
+	return c.printUsers(profile)
+}
+
+func (c *cli) newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	return flags
+}
+
+// password reads the first line of stdin with -password-stdin, and otherwise
+// prompts twice on the terminal.
+func (c *cli) password(fromStdin bool) (string, error) {
+	if fromStdin {
+		line, err := bufio.NewReader(c.stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	if c.readPassword == nil {
+		return "", errNoTerminal
+	}
+	password, err := c.readPassword("Password: ")
+	if err != nil {
+		return "", err
+	}
+	confirmation, err := c.readPassword("Confirm password: ")
+	if err != nil {
+		return "", err
+	}
+	if password != confirmation {
+		return "", errPasswordMismatch
+	}
+	return password, nil
+}
+
+var (
+	errUsage            = errors.New("usage")
+	errNoTerminal       = errors.New("stdin is not a terminal; pass the password with -password-stdin")
+	errPasswordMismatch = errors.New("passwords do not match")
+)
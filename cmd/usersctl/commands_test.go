@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
+	"github.com/raphael-foliveira/go-table-tests/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testProfile = &domain.UserProfile{
+	ID:       1,
+	Username: "testuser",
+	Email:    "test@user.com",
+	Role:     domain.RoleUser,
+}
+
+func setUpCLI(t *testing.T, output, stdin string) (*cli, *mocks.MockUsersAdminService, *bytes.Buffer) {
+	mockUsersService := mocks.NewMockUsersAdminService(t)
+	stdout := &bytes.Buffer{}
+	return &cli{
+		users:  mockUsersService,
+		stdin:  strings.NewReader(stdin),
+		stdout: stdout,
+		stderr: &bytes.Buffer{},
+		output: output,
+	}, mockUsersService, stdout
+}
+
+func TestCLI_Create(t *testing.T) {
+	c, mockUsersService, stdout := setUpCLI(t, outputJSON, "valid_password\n")
+
+	mockUsersService.EXPECT().
+		CreateUser(mock.Anything, &domain.SignupPayload{
+			Email:    "test@user.com",
+			Username: "testuser",
+			Password: "valid_password",
+		}, domain.RoleAdmin).
+		Return(testProfile, nil)
+
+	err := c.run(context.Background(), []string{
+		"create", "-email", "test@user.com", "-username", "testuser", "-role", "admin", "-password-stdin",
+	})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id": 1, "username": "testuser", "email": "test@user.com", "role": "user", "disabled": false}`, stdout.String())
+}
+
+func TestCLI_PasswordPrompt(t *testing.T) {
+	tests := []struct {
+		expectedError error
+		readPassword  func(prompt string) (string, error)
+		name          string
+	}{
+		{
+			name: "Matching confirmation",
+			readPassword: func(prompt string) (string, error) {
+				return "valid_password", nil
+			},
+		},
+		{
+			name: "Mismatched confirmation",
+			readPassword: func(prompt string) (string, error) {
+				return prompt, nil
+			},
+			expectedError: errPasswordMismatch,
+		},
+		{
+			name:          "No terminal",
+			expectedError: errNoTerminal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mockUsersService, _ := setUpCLI(t, outputTable, "")
+			c.readPassword = tt.readPassword
+
+			if tt.expectedError == nil {
+				mockUsersService.EXPECT().
+					ResetPassword(mock.Anything, "testuser", "valid_password").
+					Return(testProfile, nil)
+			}
+
+			err := c.run(context.Background(), []string{"reset-password", "testuser"})
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestCLI_Commands(t *testing.T) {
+	tests := []struct {
+		setUp    func(mockUsersService *mocks.MockUsersAdminService)
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			name: "get",
+			args: []string{"get", "test@user.com"},
+			setUp: func(mockUsersService *mocks.MockUsersAdminService) {
+				mockUsersService.EXPECT().GetUser(mock.Anything, "test@user.com").Return(testProfile, nil)
+			},
+			expected: "ID  USERNAME  EMAIL          ROLE  DISABLED\n" +
+				"1   testuser  test@user.com  user  false\n",
+		},
+		{
+			name: "disable",
+			args: []string{"disable", "1"},
+			setUp: func(mockUsersService *mocks.MockUsersAdminService) {
+				disabled := *testProfile
+				disabled.Disabled = true
+				mockUsersService.EXPECT().DisableUser(mock.Anything, "1").Return(&disabled, nil)
+			},
+			expected: "ID  USERNAME  EMAIL          ROLE  DISABLED\n" +
+				"1   testuser  test@user.com  user  true\n",
+		},
+		{
+			name: "set-role",
+			args: []string{"set-role", "testuser", "admin"},
+			setUp: func(mockUsersService *mocks.MockUsersAdminService) {
+				admin := *testProfile
+				admin.Role = domain.RoleAdmin
+				mockUsersService.EXPECT().SetRole(mock.Anything, "testuser", domain.RoleAdmin).Return(&admin, nil)
+			},
+			expected: "ID  USERNAME  EMAIL          ROLE   DISABLED\n" +
+				"1   testuser  test@user.com  admin  false\n",
+		},
+		{
+			name: "list all pages",
+			args: []string{"list", "-first", "1", "-all"},
+			setUp: func(mockUsersService *mocks.MockUsersAdminService) {
+				second := *testProfile
+				second.ID = 2
+				second.Username = "second"
+				mockUsersService.EXPECT().
+					List(mock.Anything, domain.ListUsersQuery{First: 1}).
+					Return(&domain.UsersPage{Users: []*domain.UserProfile{testProfile}, HasNextPage: true}, nil)
+				mockUsersService.EXPECT().
+					List(mock.Anything, domain.ListUsersQuery{First: 1, After: 1}).
+					Return(&domain.UsersPage{Users: []*domain.UserProfile{&second}}, nil)
+			},
+			expected: "ID  USERNAME  EMAIL          ROLE  DISABLED\n" +
+				"1   testuser  test@user.com  user  false\n" +
+				"2   second    test@user.com  user  false\n",
+		},
+		{
+			name: "list one page",
+			args: []string{"list", "-after", "5"},
+			setUp: func(mockUsersService *mocks.MockUsersAdminService) {
+				mockUsersService.EXPECT().
+					List(mock.Anything, domain.ListUsersQuery{After: 5}).
+					Return(&domain.UsersPage{Users: []*domain.UserProfile{testProfile}, HasNextPage: true}, nil)
+			},
+			expected: "ID  USERNAME  EMAIL          ROLE  DISABLED\n" +
+				"1   testuser  test@user.com  user  false\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mockUsersService, stdout := setUpCLI(t, outputTable, "")
+			tt.setUp(mockUsersService)
+
+			err := c.run(context.Background(), tt.args)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, stdout.String())
+		})
+	}
+}
+
+func TestCLI_Errors(t *testing.T) {
+	tests := []struct {
+		expectedError error
+		name          string
+		args          []string
+	}{
+		{
+			name:          "No command",
+			args:          []string{},
+			expectedError: errUsage,
+		},
+		{
+			name:          "Unknown command",
+			args:          []string{"delete", "1"},
+			expectedError: errUsage,
+		},
+		{
+			name:          "Missing create flags",
+			args:          []string{"create", "-email", "test@user.com"},
+			expectedError: errUsage,
+		},
+		{
+			name:          "Missing role",
+			args:          []string{"set-role", "testuser"},
+			expectedError: errUsage,
+		},
+		{
+			name:          "Service error",
+			args:          []string{"get", "missing"},
+			expectedError: service.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mockUsersService, _ := setUpCLI(t, outputTable, "")
+			mockUsersService.EXPECT().GetUser(mock.Anything, "missing").Return(nil, service.ErrUserNotFound).Maybe()
+
+			err := c.run(context.Background(), tt.args)
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Command usersctl manages users directly against the database, for
+// operators who cannot or should not go through the public API.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/metrics"
+	postgresRepository "github.com/raphael-foliveira/go-table-tests/internal/adapters/repository/postgres"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/security"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/tracing"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/config"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/database"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"golang.org/x/term"
+)
+
+const usage = `usage: usersctl [-env file] [-output table|json] <command> [flags] [args]
+
+commands:
+  create -email EMAIL -username USERNAME [-role user|admin] [-password-stdin]
+  get <id|email|username>
+  list [-first N] [-after ID] [-all]
+  disable <id|email|username>
+  reset-password [-password-stdin] <id|email|username>
+  set-role <id|email|username> <user|admin>
+  import [-format csv|jsonl] [-dry-run] [-continue-on-error] [-batch-size N] [-invites FILE] <FILE|->
+  export [-format csv|jsonl] [FILE|-]
+`
+
+func main() {
+	flags := flag.NewFlagSet("usersctl", flag.ExitOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	envFile := flags.String("env", ".env", "dotenv file to load the configuration from")
+	output := flags.String("output", outputTable, "output format: table or json")
+	flags.Parse(os.Args[1:])
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	if *output != outputTable && *output != outputJSON {
+		fmt.Fprintf(os.Stderr, "usersctl: unknown output format %q\n", *output)
+		os.Exit(2)
+	}
+
+	if err := config.LoadDotenv(*envFile); err != nil {
+		fail(err)
+	}
+	cfg, err := config.LoadEnv()
+	if err != nil {
+		fail(err)
+	}
+
+	// Logs go to stderr so that they never mix with the command output, and
+	// only warnings and errors are worth an operator's attention.
+	logger := logging.New(os.Stderr, logging.FormatText, max(cfg.Log.Level, slog.LevelWarn))
+
+	domain.SetEmailPolicy(domain.EmailPolicy{
+		DisposableDomains:   cfg.Email.DisposableDomains,
+		CanonicalizeAliases: cfg.Email.CanonicalizeAliases,
+	})
+
+	db, err := database.New(cfg.Database.URL.Value())
+	if err != nil {
+		fail(err)
+	}
+	defer db.Close()
+
+	usersRepository := postgresRepository.NewUsers(db, tracing.Discard())
+	hasher := security.NewHashingService(cfg.Hashing.BcryptCost)
+	unitOfWork := postgresRepository.NewUnitOfWork(db, postgresRepository.DefaultMaxAttempts, tracing.Discard())
+	usersService := service.NewUsersService(usersRepository, hasher, unitOfWork, logger, metrics.Discard(), tracing.Discard())
+
+	ctx, done := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer done()
+
+	// Whoever can run usersctl holds the database credentials, so the
+	// command acts with admin rights rather than as a particular user.
+	ctx = domain.ContextWithPrincipal(ctx, &domain.Principal{Role: domain.RoleAdmin})
+
+	c := &cli{
+		users: usersService,
+		newBulk: func(inviter ports.Inviter) ports.UsersBulkService {
+			return service.NewBulkService(usersRepository, unitOfWork, inviter, logger)
+		},
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		output: *output,
+	}
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		c.readPassword = promptPassword
+	}
+
+	err = c.run(ctx, flags.Args())
+	if errors.Is(err, errUsage) {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		db.Close()
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "usersctl: %v\n", err)
+	os.Exit(1)
+}
+
+func promptPassword(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	password, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	return string(password), err
+}
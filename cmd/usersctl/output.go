@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+type userOutput struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+	ID       uint   `json:"id"`
+	Disabled bool   `json:"disabled"`
+}
+
+// printUsers writes one JSON object per line in json mode, so that the
+// output of list can be streamed through tools such as jq.
+func (c *cli) printUsers(users ...*domain.UserProfile) error {
+	if c.output == outputJSON {
+		encoder := json.NewEncoder(c.stdout)
+		for _, user := range users {
+			err := encoder.Encode(&userOutput{
+				ID:       user.ID,
+				Username: user.Username,
+				Email:    user.Email,
+				Role:     string(user.Role),
+				Disabled: user.Disabled,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tUSERNAME\tEMAIL\tROLE\tDISABLED")
+	for _, user := range users {
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%s\t%t\n", user.ID, user.Username, user.Email, user.Role, user.Disabled)
+	}
+	return writer.Flush()
+}
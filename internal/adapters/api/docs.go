@@ -0,0 +1,122 @@
+package api
+
+import (
+	"embed"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api/openapi"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/dto"
+)
+
+const (
+	APITitle   = "go-table-tests API"
+	APIVersion = "1.0.0"
+	APIBaseURL = "/api"
+
+	// DocsAssetsPath serves the scripts and styles of the documentation
+	// page. They are static files rather than API operations, so they are
+	// not part of the OpenAPI document.
+	DocsAssetsPath = "/docs/:file"
+)
+
+//go:embed docs.html
+var docsPage []byte
+
+// docsAssets holds Swagger UI 5.18.2, vendored from swagger-ui-dist so that
+// the documentation page runs no third-party code fetched at view time, and
+// the script starting it.
+//
+//go:embed docs
+var docsAssets embed.FS
+
+// Route couples an operation's documentation with the handler serving it so
+// that registering a route and documenting it cannot drift apart.
+type Route struct {
+	Handler echo.HandlerFunc
+	openapi.Operation
+}
+
+type RouteProvider interface {
+	Routes() []Route
+}
+
+func RegisterRoutes(group *echo.Group, routes []Route) {
+	for _, route := range routes {
+		group.Add(route.Method, route.Path, route.Handler)
+	}
+}
+
+type DocsHandler struct {
+	document *openapi.Document
+}
+
+func NewDocsHandler(providers ...RouteProvider) *DocsHandler {
+	h := &DocsHandler{}
+
+	builder := openapi.NewBuilder(APITitle, APIVersion, openapi.ErrorMediaTypes{
+		"application/problem+json": dto.ProblemDetails{},
+		"application/json":         dto.ErrorResponse{},
+	}).WithServer(APIBaseURL)
+
+	for _, provider := range append(providers, h) {
+		for _, route := range provider.Routes() {
+			builder.Add(route.Operation)
+		}
+	}
+	h.document = builder.Build()
+	return h
+}
+
+func (h *DocsHandler) Document() *openapi.Document {
+	return h.document
+}
+
+// DocsContentSecurityPolicy lets the documentation page load the Swagger UI
+// assets it is served with and fetch the OpenAPI document.
+const DocsContentSecurityPolicy = "default-src 'none'; script-src 'self'; style-src 'self'; " +
+	"img-src 'self' data:; connect-src 'self'; frame-ancestors 'none'"
+
+func (h *DocsHandler) Routes() []Route {
+	return []Route{
+		{
+			Handler: h.Spec,
+			Operation: openapi.Operation{
+				Method:      http.MethodGet,
+				Path:        "/openapi.json",
+				OperationID: "getOpenAPISpec",
+				Summary:     "OpenAPI document describing this API",
+				Tag:         "docs",
+				Responses:   map[int]any{http.StatusOK: nil},
+			},
+		},
+		{
+			Handler: h.UI,
+			Operation: openapi.Operation{
+				Method:      http.MethodGet,
+				Path:        "/docs",
+				OperationID: "getDocs",
+				Summary:     "Interactive API documentation",
+				Tag:         "docs",
+				Responses:   map[int]any{http.StatusOK: nil},
+			},
+		},
+	}
+}
+
+func (h *DocsHandler) SetupRoutes(group *echo.Group) {
+	RegisterRoutes(group, h.Routes())
+	group.GET(DocsAssetsPath, h.Asset)
+}
+
+func (h *DocsHandler) Spec(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, h.document)
+}
+
+func (h *DocsHandler) UI(ctx echo.Context) error {
+	return ctx.HTMLBlob(http.StatusOK, docsPage)
+}
+
+func (h *DocsHandler) Asset(ctx echo.Context) error {
+	return echo.StaticFileHandler("docs/"+ctx.Param("file"), docsAssets)(ctx)
+}
@@ -0,0 +1,130 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocsHandler_Spec(t *testing.T) {
+	app := setUpTestServer()
+	usersHandler, _, _ := setUpDependencies(t)
+	api.NewDocsHandler(usersHandler).SetupRoutes(app.Group(api.APIBaseURL))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil)
+	recorder := httptest.NewRecorder()
+	app.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var document *openapi.Document
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&document))
+	assert.Equal(t, openapi.Version, document.OpenAPI)
+
+	tests := []struct {
+		name             string
+		schema           string
+		expectedRequired []string
+	}{
+		{
+			name:             "Login payload",
+			schema:           "LoginPayload",
+			expectedRequired: []string{"email", "password"},
+		},
+		{
+			name:             "Signup payload",
+			schema:           "SignupPayload",
+			expectedRequired: []string{"email", "username", "password"},
+		},
+		{
+			name:             "Legacy error",
+			schema:           "ErrorResponse",
+			expectedRequired: []string{"message", "code", "status_code"},
+		},
+		{
+			name:             "Problem details",
+			schema:           "ProblemDetails",
+			expectedRequired: []string{"type", "title", "code", "status"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, ok := document.Components.Schemas[tt.schema]
+			require.True(t, ok)
+			assert.ElementsMatch(t, tt.expectedRequired, schema.Required)
+		})
+	}
+
+	signup := document.Paths["/users/signup"].Post
+	require.NotNil(t, signup)
+	assert.Contains(t, signup.Responses, "201")
+	assert.Contains(t, signup.Responses["409"].Content, "application/problem+json")
+}
+
+func TestDocsHandler_UI(t *testing.T) {
+	app := setUpTestServer()
+	api.NewDocsHandler().SetupRoutes(app.Group(api.APIBaseURL))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/docs", nil)
+	recorder := httptest.NewRecorder()
+	app.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/html"))
+	assert.NotContains(t, recorder.Body.String(), "https://")
+	assert.NotContains(t, api.DocsContentSecurityPolicy, "unsafe-inline")
+}
+
+func TestDocsHandler_Asset(t *testing.T) {
+	tests := []struct {
+		name                string
+		path                string
+		expectedContentType string
+		expectedStatusCode  int
+	}{
+		{
+			name:                "Swagger UI script",
+			path:                "/api/docs/swagger-ui-bundle.js",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/javascript",
+		},
+		{
+			name:                "Swagger UI styles",
+			path:                "/api/docs/swagger-ui.css",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/css",
+		},
+		{
+			name:                "Page script",
+			path:                "/api/docs/docs.js",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/javascript",
+		},
+		{
+			name:               "Unknown asset",
+			path:               "/api/docs/index.html",
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setUpTestServer()
+			api.NewDocsHandler().SetupRoutes(app.Group(api.APIBaseURL))
+
+			recorder := httptest.NewRecorder()
+			app.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.True(t, strings.HasPrefix(recorder.Header().Get("Content-Type"), tt.expectedContentType))
+		})
+	}
+}
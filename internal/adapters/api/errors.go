@@ -0,0 +1,233 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/dto"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
+)
+
+// ErrorMapping describes how a sentinel error is presented to API clients.
+// Code is a stable, machine-readable identifier; Message is safe to expose.
+type ErrorMapping struct {
+	Err        error
+	Code       string
+	Message    string
+	Field      string
+	StatusCode int
+}
+
+// Mappings are matched in order with errors.Is, so specific errors must come
+// before the errors that wrap them (ErrInvalidUserPayload wraps every
+// domain validation error). ErrValidationFailed only matches aggregates of
+// several violations and therefore goes first.
+var (
+	errorMappings = []ErrorMapping{
+		{
+			Err:        ErrInvalidPayload,
+			StatusCode: http.StatusBadRequest,
+			Code:       "invalid_payload",
+			Message:    "invalid payload",
+		},
+		{
+			Err:        ErrPayloadTooLarge,
+			StatusCode: http.StatusRequestEntityTooLarge,
+			Code:       "payload_too_large",
+			Message:    "payload too large",
+		},
+		{
+			Err:        ErrInvalidToken,
+			StatusCode: http.StatusUnauthorized,
+			Code:       "invalid_token",
+			Message:    "invalid or expired access token",
+		},
+		{
+			Err:        ErrCSRFTokenMismatch,
+			StatusCode: http.StatusForbidden,
+			Code:       "csrf_token_invalid",
+			Message:    "missing or invalid CSRF token",
+		},
+		{
+			Err:        domain.ErrValidationFailed,
+			StatusCode: http.StatusBadRequest,
+			Code:       "validation_failed",
+			Message:    "validation failed",
+		},
+		{
+			Err:        domain.ErrEmailInvalid,
+			StatusCode: http.StatusBadRequest,
+			Code:       "email_invalid",
+			Message:    "email is not valid",
+			Field:      "email",
+		},
+		{
+			Err:        domain.ErrEmailDisposable,
+			StatusCode: http.StatusBadRequest,
+			Code:       "email_disposable",
+			Message:    "disposable email addresses are not allowed",
+			Field:      "email",
+		},
+		{
+			Err:        domain.ErrPasswordTooShort,
+			StatusCode: http.StatusBadRequest,
+			Code:       "password_too_short",
+			Message:    "password is too short",
+			Field:      "password",
+		},
+		{
+			Err:        domain.ErrPasswordTooLong,
+			StatusCode: http.StatusBadRequest,
+			Code:       "password_too_long",
+			Message:    "password is too long",
+			Field:      "password",
+		},
+		{
+			Err:        domain.ErrUsernameRequired,
+			StatusCode: http.StatusBadRequest,
+			Code:       "username_required",
+			Message:    "username is required",
+			Field:      "username",
+		},
+		{
+			Err:        domain.ErrUsernameTooShort,
+			StatusCode: http.StatusBadRequest,
+			Code:       "username_too_short",
+			Message:    "username is too short",
+			Field:      "username",
+		},
+		{
+			Err:        domain.ErrUsernameTooLong,
+			StatusCode: http.StatusBadRequest,
+			Code:       "username_too_long",
+			Message:    "username is too long",
+			Field:      "username",
+		},
+		{
+			Err:        domain.ErrUsernameInvalidCharacters,
+			StatusCode: http.StatusBadRequest,
+			Code:       "username_invalid_characters",
+			Message:    "username contains invalid characters",
+			Field:      "username",
+		},
+		{
+			Err:        domain.ErrUsernameReserved,
+			StatusCode: http.StatusBadRequest,
+			Code:       "username_reserved",
+			Message:    "username is reserved",
+			Field:      "username",
+		},
+		{
+			Err:        domain.ErrRoleInvalid,
+			StatusCode: http.StatusBadRequest,
+			Code:       "role_invalid",
+			Message:    "role is not valid",
+			Field:      "role",
+		},
+		{
+			Err:        service.ErrInvalidUserPayload,
+			StatusCode: http.StatusBadRequest,
+			Code:       "invalid_payload",
+			Message:    "invalid payload",
+		},
+		{
+			Err:        service.ErrInvalidCredentials,
+			StatusCode: http.StatusUnauthorized,
+			Code:       "invalid_credentials",
+			Message:    "invalid credentials",
+		},
+		{
+			Err:        service.ErrUnauthenticated,
+			StatusCode: http.StatusUnauthorized,
+			Code:       "unauthenticated",
+			Message:    "authentication required",
+		},
+		{
+			Err:        service.ErrForbidden,
+			StatusCode: http.StatusForbidden,
+			Code:       "forbidden",
+			Message:    "forbidden",
+		},
+		{
+			Err:        service.ErrAccountDisabled,
+			StatusCode: http.StatusForbidden,
+			Code:       "account_disabled",
+			Message:    "account disabled",
+		},
+		{
+			Err:        service.ErrUserNotFound,
+			StatusCode: http.StatusNotFound,
+			Code:       "user_not_found",
+			Message:    "user not found",
+		},
+		{
+			Err:        service.ErrEmailAlreadyTaken,
+			StatusCode: http.StatusConflict,
+			Code:       "email_taken",
+			Message:    "email already taken",
+			Field:      "email",
+		},
+		{
+			Err:        service.ErrUsernameAlreadyTaken,
+			StatusCode: http.StatusConflict,
+			Code:       "username_taken",
+			Message:    "username already taken",
+			Field:      "username",
+		},
+	}
+	errorMappingsMu sync.RWMutex
+)
+
+// RegisterError appends a mapping for an error the default table does not
+// know about. Mappings registered later never shadow earlier ones.
+func RegisterError(mapping ErrorMapping) {
+	errorMappingsMu.Lock()
+	defer errorMappingsMu.Unlock()
+
+	errorMappings = append(errorMappings, mapping)
+}
+
+func LookupError(err error) (ErrorMapping, bool) {
+	errorMappingsMu.RLock()
+	defer errorMappingsMu.RUnlock()
+
+	for _, mapping := range errorMappings {
+		if errors.Is(err, mapping.Err) {
+			return mapping, true
+		}
+	}
+	return ErrorMapping{}, false
+}
+
+// FieldViolations collects every domain.ValidationError found in err's tree,
+// following both single and multi-error Unwrap methods.
+func FieldViolations(err error) []dto.FieldViolation {
+	var violations []dto.FieldViolation
+	collectViolations(err, &violations)
+	return violations
+}
+
+func collectViolations(err error, violations *[]dto.FieldViolation) {
+	if err == nil {
+		return
+	}
+	if validationErr, ok := err.(*domain.ValidationError); ok {
+		*violations = append(*violations, dto.FieldViolation{
+			Field:  validationErr.Field,
+			Code:   validationErr.Code,
+			Detail: validationErr.Message,
+		})
+		return
+	}
+
+	switch wrapped := err.(type) {
+	case interface{ Unwrap() error }:
+		collectViolations(wrapped.Unwrap(), violations)
+	case interface{ Unwrap() []error }:
+		for _, inner := range wrapped.Unwrap() {
+			collectViolations(inner, violations)
+		}
+	}
+}
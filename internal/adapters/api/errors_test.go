@@ -0,0 +1,67 @@
+package api_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupError(t *testing.T) {
+	errCustom := errors.New("custom error")
+	api.RegisterError(api.ErrorMapping{
+		Err:        errCustom,
+		StatusCode: http.StatusTeapot,
+		Code:       "custom",
+		Message:    "custom error",
+	})
+
+	tests := []struct {
+		err                error
+		name               string
+		expectedCode       string
+		expectedStatusCode int
+		expectedFound      bool
+	}{
+		{
+			name:               "Sentinel error",
+			err:                service.ErrInvalidCredentials,
+			expectedFound:      true,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedCode:       "invalid_credentials",
+		},
+		{
+			name:               "Wrapped validation error prefers the specific cause",
+			err:                fmt.Errorf("%w: %w", service.ErrInvalidUserPayload, domain.ErrPasswordTooShort),
+			expectedFound:      true,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedCode:       "password_too_short",
+		},
+		{
+			name:               "Registered error",
+			err:                fmt.Errorf("context: %w", errCustom),
+			expectedFound:      true,
+			expectedStatusCode: http.StatusTeapot,
+			expectedCode:       "custom",
+		},
+		{
+			name:          "Unknown error",
+			err:           errors.New("unknown"),
+			expectedFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping, found := api.LookupError(tt.err)
+			assert.Equal(t, tt.expectedFound, found)
+			assert.Equal(t, tt.expectedStatusCode, mapping.StatusCode)
+			assert.Equal(t, tt.expectedCode, mapping.Code)
+		})
+	}
+}
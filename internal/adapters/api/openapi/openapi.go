@@ -0,0 +1,218 @@
+// Package openapi builds OpenAPI 3.1 documents from Go types. Schemas are
+// derived by reflection from `json` tags, with constraints taken from the
+// `schema` tag, e.g. `schema:"required,maxLength=254,format=email"`.
+package openapi
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const Version = "3.1.0"
+
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+type PathItem struct {
+	Get    *OperationObject `json:"get,omitempty"`
+	Post   *OperationObject `json:"post,omitempty"`
+	Put    *OperationObject `json:"put,omitempty"`
+	Patch  *OperationObject `json:"patch,omitempty"`
+	Delete *OperationObject `json:"delete,omitempty"`
+}
+
+type OperationObject struct {
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+}
+
+type RequestBody struct {
+	Content  map[string]*MediaType `json:"content"`
+	Required bool                  `json:"required"`
+}
+
+type Response struct {
+	Content     map[string]*MediaType `json:"content,omitempty"`
+	Description string                `json:"description"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+// Operation describes one route. Request and the values in Responses are
+// zero values of the DTOs exchanged; a nil Request means no body.
+type Operation struct {
+	Request     any
+	Responses   map[int]any
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+	Tag         string
+	// Errors lists the status codes the operation can fail with. Each is
+	// documented with every error body given to NewBuilder.
+	Errors []int
+}
+
+// ErrorMediaTypes maps every media type an error can be served as to the
+// DTO describing its body.
+type ErrorMediaTypes map[string]any
+
+type Builder struct {
+	document    *Document
+	errorBodies ErrorMediaTypes
+}
+
+func NewBuilder(title, version string, errorBodies ErrorMediaTypes) *Builder {
+	return &Builder{
+		document: &Document{
+			OpenAPI: Version,
+			Info: Info{
+				Title:   title,
+				Version: version,
+			},
+			Paths: map[string]*PathItem{},
+			Components: Components{
+				Schemas: map[string]*Schema{},
+			},
+		},
+		errorBodies: errorBodies,
+	}
+}
+
+func (b *Builder) WithServer(url string) *Builder {
+	b.document.Servers = append(b.document.Servers, Server{URL: url})
+	return b
+}
+
+func (b *Builder) Add(operations ...Operation) *Builder {
+	for _, operation := range operations {
+		b.add(operation)
+	}
+	return b
+}
+
+func (b *Builder) Build() *Document {
+	return b.document
+}
+
+func (b *Builder) add(operation Operation) {
+	object := &OperationObject{
+		OperationID: operation.OperationID,
+		Summary:     operation.Summary,
+		Responses:   map[string]*Response{},
+	}
+	if operation.Tag != "" {
+		object.Tags = []string{operation.Tag}
+	}
+
+	if operation.Request != nil {
+		object.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]*MediaType{
+				"application/json": {Schema: b.schemaRef(operation.Request)},
+			},
+		}
+	}
+
+	for status, body := range operation.Responses {
+		response := &Response{Description: http.StatusText(status)}
+		if body != nil {
+			response.Content = map[string]*MediaType{
+				"application/json": {Schema: b.schemaRef(body)},
+			}
+		}
+		object.Responses[strconv.Itoa(status)] = response
+	}
+
+	errors := append([]int(nil), operation.Errors...)
+	sort.Ints(errors)
+	for _, status := range errors {
+		response := &Response{
+			Description: http.StatusText(status),
+			Content:     map[string]*MediaType{},
+		}
+		for mediaType, body := range b.errorBodies {
+			response.Content[mediaType] = &MediaType{Schema: b.schemaRef(body)}
+		}
+		object.Responses[strconv.Itoa(status)] = response
+	}
+
+	pathItem, ok := b.document.Paths[operation.Path]
+	if !ok {
+		pathItem = &PathItem{}
+		b.document.Paths[operation.Path] = pathItem
+	}
+	pathItem.set(operation.Method, object)
+}
+
+func (p *PathItem) set(method string, operation *OperationObject) {
+	switch strings.ToUpper(method) {
+	case http.MethodGet:
+		p.Get = operation
+	case http.MethodPost:
+		p.Post = operation
+	case http.MethodPut:
+		p.Put = operation
+	case http.MethodPatch:
+		p.Patch = operation
+	case http.MethodDelete:
+		p.Delete = operation
+	}
+}
+
+// Operations returns the operations declared on the path item keyed by HTTP
+// method, which lets callers compare a document against registered routes.
+func (p *PathItem) Operations() map[string]*OperationObject {
+	operations := map[string]*OperationObject{}
+	for method, operation := range map[string]*OperationObject{
+		http.MethodGet:    p.Get,
+		http.MethodPost:   p.Post,
+		http.MethodPut:    p.Put,
+		http.MethodPatch:  p.Patch,
+		http.MethodDelete: p.Delete,
+	} {
+		if operation != nil {
+			operations[method] = operation
+		}
+	}
+	return operations
+}
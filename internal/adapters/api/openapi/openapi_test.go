@@ -0,0 +1,166 @@
+package openapi_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	Name string `json:"name" schema:"required,minLength=1,maxLength=10"`
+}
+
+type testPayload struct {
+	Email    string     `json:"email" schema:"required,format=email"`
+	Nickname string     `json:"nickname,omitempty"`
+	Order    string     `json:"order" schema:"enum=asc|desc"`
+	Items    []testItem `json:"items"`
+	Ignored  string     `json:"-"`
+	Count    uint       `json:"count"`
+	Enabled  bool       `json:"enabled"`
+}
+
+func TestBuilder_Schemas(t *testing.T) {
+	document := openapi.NewBuilder("test", "1.0.0", nil).Add(openapi.Operation{
+		Method:      http.MethodPost,
+		Path:        "/items",
+		OperationID: "createItems",
+		Request:     testPayload{},
+		Responses:   map[int]any{http.StatusCreated: nil},
+	}).Build()
+
+	payload, ok := document.Components.Schemas["testPayload"]
+	require.True(t, ok)
+
+	tests := []struct {
+		expected *openapi.Schema
+		name     string
+		property string
+	}{
+		{
+			name:     "String with format",
+			property: "email",
+			expected: &openapi.Schema{Type: "string", Format: "email"},
+		},
+		{
+			name:     "String with enum",
+			property: "order",
+			expected: &openapi.Schema{Type: "string", Enum: []string{"asc", "desc"}},
+		},
+		{
+			name:     "Array of references",
+			property: "items",
+			expected: &openapi.Schema{Type: "array", Items: &openapi.Schema{Ref: "#/components/schemas/testItem"}},
+		},
+		{
+			name:     "Unsigned integer",
+			property: "count",
+			expected: &openapi.Schema{Type: "integer", Minimum: new(int)},
+		},
+		{
+			name:     "Boolean",
+			property: "enabled",
+			expected: &openapi.Schema{Type: "boolean"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, payload.Properties[tt.property])
+		})
+	}
+
+	assert.Equal(t, []string{"email"}, payload.Required)
+	assert.NotContains(t, payload.Properties, "Ignored")
+	assert.Equal(t, 10, *document.Components.Schemas["testItem"].Properties["name"].MaxLength)
+
+	operation := document.Paths["/items"].Post
+	require.NotNil(t, operation)
+	assert.Equal(t, "#/components/schemas/testPayload", operation.RequestBody.Content["application/json"].Schema.Ref)
+}
+
+func TestDocument_ResponseSchema(t *testing.T) {
+	document := openapi.NewBuilder("test", "1.0.0", openapi.ErrorMediaTypes{
+		"application/problem+json": testItem{},
+	}).Add(openapi.Operation{
+		Method:      http.MethodPost,
+		Path:        "/items",
+		OperationID: "createItems",
+		Request:     testPayload{},
+		Responses:   map[int]any{http.StatusCreated: testItem{}, http.StatusNoContent: nil},
+		Errors:      []int{http.StatusConflict},
+	}).Build()
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		mediaType   string
+		expectedRef string
+		status      int
+		expectedOK  bool
+	}{
+		{
+			name:        "Documented body",
+			method:      http.MethodPost,
+			path:        "/items",
+			status:      http.StatusCreated,
+			mediaType:   "application/json",
+			expectedRef: "#/components/schemas/testItem",
+			expectedOK:  true,
+		},
+		{
+			name:        "Documented error body",
+			method:      http.MethodPost,
+			path:        "/items",
+			status:      http.StatusConflict,
+			mediaType:   "application/problem+json",
+			expectedRef: "#/components/schemas/testItem",
+			expectedOK:  true,
+		},
+		{
+			name:       "Response without a body",
+			method:     http.MethodPost,
+			path:       "/items",
+			status:     http.StatusNoContent,
+			expectedOK: true,
+		},
+		{
+			name:      "Undocumented media type",
+			method:    http.MethodPost,
+			path:      "/items",
+			status:    http.StatusConflict,
+			mediaType: "application/json",
+		},
+		{
+			name:      "Undocumented status",
+			method:    http.MethodPost,
+			path:      "/items",
+			status:    http.StatusNotFound,
+			mediaType: "application/json",
+		},
+		{
+			name:   "Undocumented operation",
+			method: http.MethodGet,
+			path:   "/items",
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, ok := document.ResponseSchema(tt.method, tt.path, tt.status, tt.mediaType)
+
+			assert.Equal(t, tt.expectedOK, ok)
+			if tt.expectedRef == "" {
+				assert.Nil(t, schema)
+				return
+			}
+			require.NotNil(t, schema)
+			assert.Equal(t, tt.expectedRef, schema.Ref)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// schemaRef registers the schema of v's type under components and returns a
+// reference to it. Anonymous types are inlined.
+func (b *Builder) schemaRef(v any) *Schema {
+	return b.schemaFor(reflect.TypeOf(v))
+}
+
+func (b *Builder) schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Minimum: intPointer(0)}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: b.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object"}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		if _, ok := b.document.Components.Schemas[t.Name()]; !ok {
+			// Reserve the name first so recursive types terminate.
+			b.document.Components.Schemas[t.Name()] = &Schema{}
+			*b.document.Components.Schemas[t.Name()] = *b.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	}
+	return &Schema{}
+}
+
+func (b *Builder) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:                 "object",
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: boolPointer(false),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+
+		property := b.schemaFor(field.Type)
+		required := applyConstraints(property, field.Tag.Get("schema"))
+		schema.Properties[name] = property
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
+
+func jsonName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
+
+// applyConstraints copies the options of a `schema` tag onto schema and
+// reports whether the property is required.
+func applyConstraints(schema *Schema, tag string) bool {
+	required := false
+	for _, option := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch key {
+		case "required":
+			required = true
+		case "format":
+			schema.Format = value
+		case "minLength":
+			schema.MinLength = atoiPointer(value)
+		case "maxLength":
+			schema.MaxLength = atoiPointer(value)
+		case "enum":
+			// Options are separated by commas, so values are separated by
+			// pipes: `schema:"enum=a|b"`.
+			schema.Enum = strings.Split(value, "|")
+		}
+	}
+	return required
+}
+
+func atoiPointer(value string) *int {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+func intPointer(n int) *int {
+	return &n
+}
+
+func boolPointer(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,185 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Violation is a single way in which a value fails its schema. Path uses dots
+// for properties and brackets for array items, e.g. "items[0].name".
+type Violation struct {
+	Path    string
+	Code    string
+	Message string
+}
+
+// RequestSchema returns the JSON request body schema of the operation
+// registered for method and path, or nil when it takes no body.
+func (d *Document) RequestSchema(method, path string) *Schema {
+	operation := d.operation(method, path)
+	if operation == nil || operation.RequestBody == nil {
+		return nil
+	}
+	mediaType, ok := operation.RequestBody.Content["application/json"]
+	if !ok {
+		return nil
+	}
+	return mediaType.Schema
+}
+
+// ResponseSchema returns the schema of the body the operation registered for
+// method and path sends with status as mediaType. ok is false when that
+// response is not documented. Responses documented without a body match any
+// media type and have a nil schema.
+func (d *Document) ResponseSchema(method, path string, status int, mediaType string) (schema *Schema, ok bool) {
+	operation := d.operation(method, path)
+	if operation == nil {
+		return nil, false
+	}
+	response, ok := operation.Responses[strconv.Itoa(status)]
+	if !ok {
+		return nil, false
+	}
+	if len(response.Content) == 0 {
+		return nil, true
+	}
+	content, ok := response.Content[mediaType]
+	if !ok {
+		return nil, false
+	}
+	return content.Schema, true
+}
+
+func (d *Document) operation(method, path string) *OperationObject {
+	pathItem, ok := d.Paths[path]
+	if !ok {
+		return nil
+	}
+	return pathItem.Operations()[strings.ToUpper(method)]
+}
+
+// Validate checks value, as produced by decoding JSON with UseNumber, against
+// schema and returns every violation found, in a stable order.
+func (d *Document) Validate(schema *Schema, value any) []Violation {
+	var violations []Violation
+	d.validate(schema, value, "", &violations)
+	return violations
+}
+
+func (d *Document) validate(schema *Schema, value any, path string, violations *[]Violation) {
+	schema = d.resolve(schema)
+	if schema == nil {
+		return
+	}
+
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			addViolation(violations, path, "invalid_type", "must be an object")
+			return
+		}
+		d.validateObject(schema, object, path, violations)
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			addViolation(violations, path, "invalid_type", "must be an array")
+			return
+		}
+		for i, item := range items {
+			d.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i), violations)
+		}
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			addViolation(violations, path, "invalid_type", "must be a string")
+			return
+		}
+		validateLength(schema, text, path, violations)
+		if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, text) {
+			addViolation(violations, path, "invalid_value", "must be one of "+strings.Join(schema.Enum, ", "))
+		}
+	case "integer":
+		number, ok := value.(json.Number)
+		if _, err := number.Int64(); !ok || err != nil {
+			addViolation(violations, path, "invalid_type", "must be an integer")
+			return
+		}
+		if schema.Minimum != nil {
+			if n, _ := number.Int64(); n < int64(*schema.Minimum) {
+				addViolation(violations, path, "too_small", fmt.Sprintf("must be at least %d", *schema.Minimum))
+			}
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			addViolation(violations, path, "invalid_type", "must be a number")
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			addViolation(violations, path, "invalid_type", "must be a boolean")
+		}
+	}
+}
+
+func (d *Document) validateObject(schema *Schema, object map[string]any, path string, violations *[]Violation) {
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			addViolation(violations, joinPath(path, name), "required", "is required")
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := schema.Properties[name]
+		if !ok {
+			if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+				addViolation(violations, joinPath(path, name), "unknown_field", "is not a known field")
+			}
+			continue
+		}
+		d.validate(property, object[name], joinPath(path, name), violations)
+	}
+}
+
+func validateLength(schema *Schema, text, path string, violations *[]Violation) {
+	length := utf8.RuneCountInString(text)
+	if schema.MinLength != nil && length < *schema.MinLength {
+		addViolation(violations, path, "too_short", fmt.Sprintf("must be at least %d characters long", *schema.MinLength))
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		addViolation(violations, path, "too_long", fmt.Sprintf("must be at most %d characters long", *schema.MaxLength))
+	}
+}
+
+func (d *Document) resolve(schema *Schema) *Schema {
+	if schema == nil || schema.Ref == "" {
+		return schema
+	}
+	name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+	return d.resolve(d.Components.Schemas[name])
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func addViolation(violations *[]Violation, path, code, message string) {
+	*violations = append(*violations, Violation{
+		Path:    path,
+		Code:    code,
+		Message: message,
+	})
+}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+)
+
+// Session modes a login can request. Bearer, the default, returns the access
+// token in the body for API clients to send in the Authorization header.
+// Cookie keeps it in an HttpOnly cookie, out of reach of scripts, for
+// browsers.
+const (
+	SessionBearer = "bearer"
+	SessionCookie = "cookie"
+)
+
+// TokenTypeBearer is the token type reported alongside bearer tokens.
+const TokenTypeBearer = "Bearer"
+
+const (
+	SessionCookieName = "session"
+	CSRFCookieName    = "csrf_token"
+	// CSRFHeader must repeat the value of the CSRF cookie on every
+	// state-changing request authenticated by the session cookie.
+	CSRFHeader = "X-CSRF-Token"
+
+	csrfTokenLength = 32
+)
+
+// SessionOptions are the attributes of the session and CSRF cookies.
+type SessionOptions struct {
+	Domain   string
+	Path     string
+	SameSite http.SameSite
+	Secure   bool
+}
+
+func DefaultSessionOptions() SessionOptions {
+	return SessionOptions{
+		Path:     "/",
+		SameSite: http.SameSiteStrictMode,
+		Secure:   true,
+	}
+}
+
+// Sessions authenticates requests with the access tokens of ports.TokenService,
+// sent either as bearer tokens or in the session cookie. Cookie sessions are
+// protected against CSRF with a double-submit token: a second cookie, readable
+// by the page's scripts, whose value must be echoed in CSRFHeader. Other
+// origins can neither read the cookie nor set the header.
+type Sessions struct {
+	tokens  ports.TokenService
+	options SessionOptions
+}
+
+func NewSessions(tokens ports.TokenService, options SessionOptions) *Sessions {
+	return &Sessions{tokens: tokens, options: options}
+}
+
+// Authenticate attaches the principal of the bearer token or, when there is
+// no Authorization header, of the session cookie to the request context.
+// Requests without credentials go through anonymous, and the services reject
+// them where a principal is required. An invalid bearer token is rejected,
+// while an invalid session cookie is ignored so that a browser holding a
+// stale one can still log in again.
+func (s *Sessions) Authenticate() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			req := ctx.Request()
+			if authorization := req.Header.Get(echo.HeaderAuthorization); authorization != "" {
+				scheme, token, _ := strings.Cut(authorization, " ")
+				if !strings.EqualFold(scheme, TokenTypeBearer) || token == "" {
+					return ErrInvalidToken
+				}
+				principal, err := s.tokens.Verify(token)
+				if err != nil {
+					return fmt.Errorf("%w: %w", ErrInvalidToken, err)
+				}
+				return next(withPrincipal(ctx, principal))
+			}
+
+			cookie, err := ctx.Cookie(SessionCookieName)
+			if err != nil {
+				return next(ctx)
+			}
+			principal, err := s.tokens.Verify(cookie.Value)
+			if err != nil {
+				return next(ctx)
+			}
+			if !safeMethod(req.Method) {
+				if err := checkCSRF(ctx); err != nil {
+					return err
+				}
+			}
+			return next(withPrincipal(ctx, principal))
+		}
+	}
+}
+
+// start sets the session and CSRF cookies for token and returns the CSRF
+// token.
+func (s *Sessions) start(ctx echo.Context, token *domain.AccessToken) (string, error) {
+	random := make([]byte, csrfTokenLength)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	csrfToken := base64.RawURLEncoding.EncodeToString(random)
+
+	maxAge := int(time.Until(token.ExpiresAt).Seconds())
+	session := s.cookie(SessionCookieName, token.Value, maxAge)
+	session.HttpOnly = true
+	ctx.SetCookie(session)
+	ctx.SetCookie(s.cookie(CSRFCookieName, csrfToken, maxAge))
+	return csrfToken, nil
+}
+
+// end expires the session and CSRF cookies.
+func (s *Sessions) end(ctx echo.Context) {
+	session := s.cookie(SessionCookieName, "", -1)
+	session.HttpOnly = true
+	ctx.SetCookie(session)
+	ctx.SetCookie(s.cookie(CSRFCookieName, "", -1))
+}
+
+func (s *Sessions) cookie(name, value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Domain:   s.options.Domain,
+		Path:     s.options.Path,
+		MaxAge:   maxAge,
+		Secure:   s.options.Secure,
+		SameSite: s.options.SameSite,
+	}
+}
+
+func checkCSRF(ctx echo.Context) error {
+	cookie, err := ctx.Cookie(CSRFCookieName)
+	header := ctx.Request().Header.Get(CSRFHeader)
+	if err != nil || cookie.Value == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(header)) != 1 {
+		return ErrCSRFTokenMismatch
+	}
+	return nil
+}
+
+func safeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+func withPrincipal(ctx echo.Context, principal *domain.Principal) echo.Context {
+	req := ctx.Request()
+	ctx.SetRequest(req.WithContext(domain.ContextWithPrincipal(req.Context(), principal)))
+	return ctx
+}
+
+// ParseSameSite accepts "strict", "lax" and "none".
+func ParseSameSite(value string) (http.SameSite, error) {
+	switch strings.ToLower(value) {
+	case "strict":
+		return http.SameSiteStrictMode, nil
+	case "lax":
+		return http.SameSiteLaxMode, nil
+	case "none":
+		return http.SameSiteNoneMode, nil
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidSameSite, value)
+}
+
+var (
+	ErrInvalidToken      = errors.New("invalid access token")
+	ErrCSRFTokenMismatch = errors.New("missing or invalid CSRF token")
+	ErrInvalidSameSite   = errors.New("invalid SameSite mode")
+)
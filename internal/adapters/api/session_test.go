@@ -0,0 +1,237 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/metrics"
+	memoryRepository "github.com/raphael-foliveira/go-table-tests/internal/adapters/repository/memory"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/security"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/tracing"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"github.com/raphael-foliveira/go-table-tests/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessions_Authenticate(t *testing.T) {
+	user := &domain.Principal{Role: domain.RoleUser, UserID: 7}
+
+	tests := []struct {
+		expectedErr       error
+		expectedPrincipal *domain.Principal
+		cookies           map[string]string
+		headers           map[string]string
+		testName          string
+		method            string
+	}{
+		{
+			testName: "Anonymous",
+			method:   http.MethodPost,
+		},
+		{
+			testName:          "Bearer token",
+			method:            http.MethodPost,
+			headers:           map[string]string{echo.HeaderAuthorization: "Bearer valid"},
+			expectedPrincipal: user,
+		},
+		{
+			testName:    "Invalid bearer token",
+			method:      http.MethodGet,
+			headers:     map[string]string{echo.HeaderAuthorization: "Bearer forged"},
+			expectedErr: api.ErrInvalidToken,
+		},
+		{
+			testName:    "Unsupported authorization scheme",
+			method:      http.MethodGet,
+			headers:     map[string]string{echo.HeaderAuthorization: "Basic dXNlcjpwYXNz"},
+			expectedErr: api.ErrInvalidToken,
+		},
+		{
+			testName:          "Session cookie on a safe method",
+			method:            http.MethodGet,
+			cookies:           map[string]string{api.SessionCookieName: "valid"},
+			expectedPrincipal: user,
+		},
+		{
+			testName:          "Session cookie with CSRF token",
+			method:            http.MethodPost,
+			cookies:           map[string]string{api.SessionCookieName: "valid", api.CSRFCookieName: "csrf"},
+			headers:           map[string]string{api.CSRFHeader: "csrf"},
+			expectedPrincipal: user,
+		},
+		{
+			testName:    "Session cookie without CSRF header",
+			method:      http.MethodPost,
+			cookies:     map[string]string{api.SessionCookieName: "valid", api.CSRFCookieName: "csrf"},
+			expectedErr: api.ErrCSRFTokenMismatch,
+		},
+		{
+			testName:    "Session cookie with mismatched CSRF header",
+			method:      http.MethodDelete,
+			cookies:     map[string]string{api.SessionCookieName: "valid", api.CSRFCookieName: "csrf"},
+			headers:     map[string]string{api.CSRFHeader: "other"},
+			expectedErr: api.ErrCSRFTokenMismatch,
+		},
+		{
+			testName:    "Session cookie without CSRF cookie",
+			method:      http.MethodPost,
+			cookies:     map[string]string{api.SessionCookieName: "valid"},
+			headers:     map[string]string{api.CSRFHeader: ""},
+			expectedErr: api.ErrCSRFTokenMismatch,
+		},
+		{
+			testName: "Stale session cookie is ignored",
+			method:   http.MethodPost,
+			cookies:  map[string]string{api.SessionCookieName: "forged"},
+		},
+		{
+			testName:          "Bearer token takes precedence over the session cookie",
+			method:            http.MethodPost,
+			cookies:           map[string]string{api.SessionCookieName: "forged"},
+			headers:           map[string]string{echo.HeaderAuthorization: "Bearer valid"},
+			expectedPrincipal: user,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			mockTokenService := mocks.NewMockTokenService(t)
+			mockTokenService.EXPECT().Verify("valid").Return(user, nil).Maybe()
+			mockTokenService.EXPECT().Verify("forged").Return(nil, assert.AnError).Maybe()
+			sessions := api.NewSessions(mockTokenService, api.DefaultSessionOptions())
+
+			req := httptest.NewRequest(tt.method, "/", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			for name, value := range tt.cookies {
+				req.AddCookie(&http.Cookie{Name: name, Value: value})
+			}
+			ctx := echo.New().NewContext(req, httptest.NewRecorder())
+
+			var principal *domain.Principal
+			err := sessions.Authenticate()(func(ctx echo.Context) error {
+				principal, _ = domain.PrincipalFromContext(ctx.Request().Context())
+				return nil
+			})(ctx)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedPrincipal, principal)
+		})
+	}
+}
+
+func TestSessions_RevokedAdmin(t *testing.T) {
+	tests := []struct {
+		revoke      func(ctx context.Context, usersService *service.Users) error
+		expectedErr error
+		testName    string
+	}{
+		{
+			testName: "Disabled while holding a live token",
+			revoke: func(ctx context.Context, usersService *service.Users) error {
+				_, err := usersService.DisableUser(ctx, "operator")
+				return err
+			},
+			expectedErr: service.ErrAccountDisabled,
+		},
+		{
+			testName: "Demoted while holding a live token",
+			revoke: func(ctx context.Context, usersService *service.Users) error {
+				_, err := usersService.SetRole(ctx, "operator", domain.RoleUser)
+				return err
+			},
+			expectedErr: service.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			hasherMock := mocks.NewMockHasher(t)
+			hasherMock.EXPECT().Hash(mock.Anything).Return("hashedPassword", nil).Maybe()
+			users := memoryRepository.NewUsers()
+			usersService := service.NewUsersService(users, hasherMock, memoryRepository.NewUnitOfWork(users), logging.Discard(), metrics.Discard(), tracing.Discard())
+			operator := domain.ContextWithPrincipal(context.Background(), &domain.Principal{Role: domain.RoleAdmin})
+			admin, err := usersService.CreateUser(operator, &domain.SignupPayload{
+				Email:    "admin@user.com",
+				Username: "operator",
+				Password: "unhashedPassword",
+			}, domain.RoleAdmin)
+			require.NoError(t, err)
+
+			tokens, err := security.NewTokens([]byte(strings.Repeat("k", security.MinSigningKeyLength)), time.Hour)
+			require.NoError(t, err)
+			token, err := tokens.Issue(&domain.Principal{Role: admin.Role, UserID: admin.ID})
+			require.NoError(t, err)
+
+			app := echo.New()
+			app.Use(api.NewSessions(tokens, api.DefaultSessionOptions()).Authenticate())
+			app.GET("/users", func(ctx echo.Context) error {
+				_, err := usersService.List(ctx.Request().Context(), domain.ListUsersQuery{})
+				return err
+			})
+			var handlerErr error
+			app.HTTPErrorHandler = func(err error, ctx echo.Context) {
+				handlerErr = err
+			}
+			request := func() error {
+				handlerErr = nil
+				req := httptest.NewRequest(http.MethodGet, "/users", nil)
+				req.Header.Set(echo.HeaderAuthorization, "Bearer "+token.Value)
+				app.ServeHTTP(httptest.NewRecorder(), req)
+				return handlerErr
+			}
+
+			require.NoError(t, request())
+			require.NoError(t, tt.revoke(operator, usersService))
+			assert.ErrorIs(t, request(), tt.expectedErr)
+		})
+	}
+}
+
+func TestUserHandler_Logout(t *testing.T) {
+	usersHandler, _, _ := setUpDependencies(t)
+	recorder := httptest.NewRecorder()
+	ctx := echo.New().NewContext(httptest.NewRequest(http.MethodPost, "/api/users/logout", nil), recorder)
+
+	require.NoError(t, usersHandler.Logout(ctx))
+
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	cookies := recorder.Result().Cookies()
+	require.Len(t, cookies, 2)
+	for _, cookie := range cookies {
+		assert.Empty(t, cookie.Value)
+		assert.Negative(t, cookie.MaxAge)
+	}
+}
+
+func TestParseSameSite(t *testing.T) {
+	tests := []struct {
+		expectedErr error
+		value       string
+		expected    http.SameSite
+	}{
+		{value: "strict", expected: http.SameSiteStrictMode},
+		{value: "Lax", expected: http.SameSiteLaxMode},
+		{value: "none", expected: http.SameSiteNoneMode},
+		{value: "always", expectedErr: api.ErrInvalidSameSite},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			sameSite, err := api.ParseSameSite(tt.value)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, sameSite)
+		})
+	}
+}
@@ -1,30 +1,73 @@
 package api
 
 import (
-	"errors"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api/openapi"
 	"github.com/raphael-foliveira/go-table-tests/internal/adapters/dto"
 	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
 	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
-	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
 )
 
 type UsersHandler struct {
 	usersService ports.UsersService
+	sessions     *Sessions
 }
 
-func NewUsersHandler(usersService ports.UsersService) *UsersHandler {
+func NewUsersHandler(usersService ports.UsersService, sessions *Sessions) *UsersHandler {
 	return &UsersHandler{
 		usersService: usersService,
+		sessions:     sessions,
+	}
+}
+
+func (h *UsersHandler) Routes() []Route {
+	return []Route{
+		{
+			Handler: h.Login,
+			Operation: openapi.Operation{
+				Method:      http.MethodPost,
+				Path:        "/users/login",
+				OperationID: "login",
+				Summary:     "Authenticate with email and password",
+				Tag:         "users",
+				Request:     dto.LoginPayload{},
+				Responses:   map[int]any{http.StatusOK: dto.LoginResponse{}},
+				Errors:      []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError},
+			},
+		},
+		{
+			Handler: h.Logout,
+			Operation: openapi.Operation{
+				Method:      http.MethodPost,
+				Path:        "/users/logout",
+				OperationID: "logout",
+				Summary:     "End a cookie session",
+				Tag:         "users",
+				Responses:   map[int]any{http.StatusNoContent: nil},
+				Errors:      []int{http.StatusForbidden},
+			},
+		},
+		{
+			Handler: h.Signup,
+			Operation: openapi.Operation{
+				Method:      http.MethodPost,
+				Path:        "/users/signup",
+				OperationID: "signup",
+				Summary:     "Create a new account",
+				Tag:         "users",
+				Request:     dto.SignupPayload{},
+				Responses:   map[int]any{http.StatusCreated: dto.SignupResponse{}},
+				Errors:      []int{http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError},
+			},
+		},
 	}
 }
 
 func (h *UsersHandler) SetupRoutes(group *echo.Group) {
-	usersGroup := group.Group("/users")
-	usersGroup.POST("/login", h.Login)
-	usersGroup.POST("/signup", h.Signup)
+	RegisterRoutes(group, h.Routes())
 }
 
 func (h *UsersHandler) Login(ctx echo.Context) error {
@@ -33,18 +76,41 @@ func (h *UsersHandler) Login(ctx echo.Context) error {
 		return ErrInvalidPayload
 	}
 
-	loginResponse, err := h.usersService.Login(loginPayload.Email, loginPayload.Password)
+	loginResponse, err := h.usersService.Login(ctx.Request().Context(), loginPayload.Email, loginPayload.Password)
 	if err != nil {
-		if errors.Is(err, service.ErrInvalidCredentials) {
-			return ErrInvalidCredentials
-		}
 		return err
 	}
 
-	return ctx.JSON(http.StatusOK, &dto.LoginResponse{
-		Email:    loginResponse.Email,
-		Username: loginResponse.Username,
+	token, err := h.sessions.tokens.Issue(&domain.Principal{
+		Role:   loginResponse.Role,
+		UserID: loginResponse.UserID,
 	})
+	if err != nil {
+		return err
+	}
+
+	response := &dto.LoginResponse{
+		Email:     loginResponse.Email,
+		Username:  loginResponse.Username,
+		ExpiresIn: int(time.Until(token.ExpiresAt).Round(time.Second).Seconds()),
+	}
+	if loginPayload.Session == SessionCookie {
+		response.CSRFToken, err = h.sessions.start(ctx, token)
+		if err != nil {
+			return err
+		}
+	} else {
+		response.AccessToken = token.Value
+		response.TokenType = TokenTypeBearer
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// Logout clears the session cookies. Bearer tokens are stateless and stay
+// valid until they expire; clients discard them instead.
+func (h *UsersHandler) Logout(ctx echo.Context) error {
+	h.sessions.end(ctx)
+	return ctx.NoContent(http.StatusNoContent)
 }
 
 func (h *UsersHandler) Signup(ctx echo.Context) error {
@@ -53,13 +119,13 @@ func (h *UsersHandler) Signup(ctx echo.Context) error {
 		return ErrInvalidPayload
 	}
 
-	signupResponse, err := h.usersService.Signup(&domain.SignupPayload{
+	signupResponse, err := h.usersService.Signup(ctx.Request().Context(), &domain.SignupPayload{
 		Email:    signupPayload.Email,
 		Username: signupPayload.Username,
 		Password: signupPayload.Password,
 	})
 	if err != nil {
-		return echo.ErrInternalServerError
+		return err
 	}
 
 	return ctx.JSON(http.StatusCreated, &dto.SignupResponse{
@@ -70,6 +136,6 @@ func (h *UsersHandler) Signup(ctx echo.Context) error {
 }
 
 var (
-	ErrInvalidPayload     = echo.NewHTTPError(400, "invalid payload")
-	ErrInvalidCredentials = echo.NewHTTPError(401, "invalid credentials")
+	ErrInvalidPayload  = echo.NewHTTPError(400, "invalid payload")
+	ErrPayloadTooLarge = echo.NewHTTPError(413, "payload too large")
 )
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api"
@@ -16,21 +17,23 @@ import (
 	"github.com/raphael-foliveira/go-table-tests/pkg/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func setUpTestServer() *echo.Echo {
 	return echo.New()
 }
 
-func setUpDependencies(t *testing.T) (*api.UsersHandler, *mocks.MockUsersService) {
+func setUpDependencies(t *testing.T) (*api.UsersHandler, *mocks.MockUsersService, *mocks.MockTokenService) {
 	mockUsersService := mocks.NewMockUsersService(t)
-	usersHandler := api.NewUsersHandler(mockUsersService)
-	return usersHandler, mockUsersService
+	mockTokenService := mocks.NewMockTokenService(t)
+	usersHandler := api.NewUsersHandler(mockUsersService, api.NewSessions(mockTokenService, api.DefaultSessionOptions()))
+	return usersHandler, mockUsersService, mockTokenService
 }
 
 func TestUserHandler_SetupRoutes(t *testing.T) {
 	app := setUpTestServer()
-	usersHandler, _ := setUpDependencies(t)
+	usersHandler, _, _ := setUpDependencies(t)
 
 	apiGroup := app.Group("/api")
 
@@ -44,44 +47,73 @@ func TestUserHandler_SetupRoutes(t *testing.T) {
 			testName:      "Login Route",
 			expectedRoute: "/api/users/login",
 		},
+		{
+			testName:      "Logout Route",
+			expectedRoute: "/api/users/logout",
+		},
 		{
 			testName:      "Signup Route",
 			expectedRoute: "/api/users/signup",
 		},
 	}
 
-	for i, tt := range tests {
+	var paths []string
+	for _, route := range app.Routes() {
+		paths = append(paths, route.Path)
+	}
+
+	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
-			routes := app.Routes()
-			assert.Equal(t, tt.expectedRoute, routes[i].Path)
+			assert.Contains(t, paths, tt.expectedRoute)
 		})
 	}
 }
 
 func TestUserHandlerLogin_Success(t *testing.T) {
+	token := &domain.AccessToken{Value: "signed.token", ExpiresAt: time.Now().Add(15 * time.Minute)}
+
 	tests := []struct {
-		mockLoginResponse *domain.LoginResponse
-		testName          string
-		loginJson         string
+		mockLoginResponse   *domain.LoginResponse
+		expectedAccessToken string
+		testName            string
+		loginJson           string
+		expectCookies       bool
 	}{
 		{
-			testName:  "Success",
+			testName:  "Bearer session by default",
 			loginJson: `{"email": "valid@user.com", "password": "unhashedPassword"}`,
 			mockLoginResponse: &domain.LoginResponse{
 				Username: "validuser",
 				Email:    "valid@user.com",
+				Role:     domain.RoleUser,
+				UserID:   7,
+			},
+			expectedAccessToken: token.Value,
+		},
+		{
+			testName:  "Cookie session",
+			loginJson: `{"email": "valid@user.com", "password": "unhashedPassword", "session": "cookie"}`,
+			mockLoginResponse: &domain.LoginResponse{
+				Username: "validuser",
+				Email:    "valid@user.com",
+				Role:     domain.RoleUser,
+				UserID:   7,
 			},
+			expectCookies: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
 			testServer := setUpTestServer()
-			usersHandler, mockUsersService := setUpDependencies(t)
+			usersHandler, mockUsersService, mockTokenService := setUpDependencies(t)
 
 			mockUsersService.EXPECT().
-				Login(mock.Anything, mock.Anything).
+				Login(mock.Anything, mock.Anything, mock.Anything).
 				Return(tt.mockLoginResponse, nil)
+			mockTokenService.EXPECT().
+				Issue(&domain.Principal{Role: tt.mockLoginResponse.Role, UserID: tt.mockLoginResponse.UserID}).
+				Return(token, nil)
 
 			req := httptest.NewRequest(http.MethodPost, "/api/users/login", strings.NewReader(tt.loginJson))
 			req.Header.Add("Content-Type", "application/json")
@@ -96,6 +128,30 @@ func TestUserHandlerLogin_Success(t *testing.T) {
 			json.NewDecoder(recorder.Body).Decode(&responseBody)
 			assert.Equal(t, tt.mockLoginResponse.Email, responseBody.Email)
 			assert.Equal(t, tt.mockLoginResponse.Username, responseBody.Username)
+			assert.Equal(t, tt.expectedAccessToken, responseBody.AccessToken)
+			assert.InDelta(t, 15*60, responseBody.ExpiresIn, 1)
+
+			cookies := map[string]*http.Cookie{}
+			for _, cookie := range recorder.Result().Cookies() {
+				cookies[cookie.Name] = cookie
+			}
+			if !tt.expectCookies {
+				assert.Empty(t, cookies)
+				assert.Empty(t, responseBody.CSRFToken)
+				return
+			}
+
+			require.Contains(t, cookies, api.SessionCookieName)
+			require.Contains(t, cookies, api.CSRFCookieName)
+			session := cookies[api.SessionCookieName]
+			assert.Equal(t, token.Value, session.Value)
+			assert.True(t, session.HttpOnly)
+			assert.True(t, session.Secure)
+			assert.Equal(t, http.SameSiteStrictMode, session.SameSite)
+			assert.Equal(t, "/", session.Path)
+			assert.False(t, cookies[api.CSRFCookieName].HttpOnly)
+			assert.NotEmpty(t, responseBody.CSRFToken)
+			assert.Equal(t, responseBody.CSRFToken, cookies[api.CSRFCookieName].Value)
 		})
 	}
 }
@@ -119,11 +175,14 @@ func TestUserHandlerLogin_InvalidBody(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
 			testServer := setUpTestServer()
-			usersHandler, mockUsersService := setUpDependencies(t)
+			usersHandler, mockUsersService, mockTokenService := setUpDependencies(t)
 
 			mockUsersService.EXPECT().
-				Login(mock.Anything, mock.Anything).
+				Login(mock.Anything, mock.Anything, mock.Anything).
 				Return(tt.mockLoginResponse, nil)
+			mockTokenService.EXPECT().
+				Issue(mock.Anything).
+				Return(&domain.AccessToken{Value: "signed.token"}, nil)
 
 			req := httptest.NewRequest(http.MethodPost, "/api/users/login", strings.NewReader(tt.loginJson))
 			req.Header.Add("Content-Type", "application/json")
@@ -156,10 +215,10 @@ func TestUserHandlerLogin_InvalidCredentials(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
 			testServer := setUpTestServer()
-			usersHandler, mockUsersService := setUpDependencies(t)
+			usersHandler, mockUsersService, _ := setUpDependencies(t)
 
 			mockUsersService.EXPECT().
-				Login(mock.Anything, mock.Anything).
+				Login(mock.Anything, mock.Anything, mock.Anything).
 				Return(nil, service.ErrInvalidCredentials)
 
 			req := httptest.NewRequest(http.MethodPost, "/api/users/login", strings.NewReader(tt.loginJson))
@@ -169,7 +228,7 @@ func TestUserHandlerLogin_InvalidCredentials(t *testing.T) {
 			ctx := testServer.NewContext(req, recorder)
 
 			err := usersHandler.Login(ctx)
-			assert.Equal(t, api.ErrInvalidCredentials, err)
+			assert.ErrorIs(t, err, service.ErrInvalidCredentials)
 		})
 	}
 }
@@ -185,6 +244,7 @@ func TestUserHandler_Signup(t *testing.T) {
 		signupPassword        string
 	}{
 		{
+			testName:       "Success",
 			signupUsername: "testuser",
 			signupEmail:    "test@user.com",
 			signupPassword: "unhashedPassword",
@@ -194,14 +254,30 @@ func TestUserHandler_Signup(t *testing.T) {
 				Email:    "test@user.com",
 			},
 		},
+		{
+			testName:           "Email already taken",
+			signupUsername:     "testuser",
+			signupEmail:        "taken@user.com",
+			signupPassword:     "unhashedPassword",
+			signupServiceError: service.ErrEmailAlreadyTaken,
+			expectedError:      service.ErrEmailAlreadyTaken,
+		},
+		{
+			testName:           "Username already taken",
+			signupUsername:     "takenuser",
+			signupEmail:        "test@user.com",
+			signupPassword:     "unhashedPassword",
+			signupServiceError: service.ErrUsernameAlreadyTaken,
+			expectedError:      service.ErrUsernameAlreadyTaken,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
 			testServer := setUpTestServer()
-			usersHandler, mockUsersService := setUpDependencies(t)
+			usersHandler, mockUsersService, _ := setUpDependencies(t)
 
-			mockUsersService.EXPECT().Signup(&domain.SignupPayload{
+			mockUsersService.EXPECT().Signup(mock.Anything, &domain.SignupPayload{
 				Username: tt.signupUsername,
 				Email:    tt.signupEmail,
 				Password: tt.signupPassword,
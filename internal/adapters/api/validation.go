@@ -0,0 +1,65 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api/openapi"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+)
+
+// ValidateRequests rejects request bodies that do not match the schema
+// documented for their route before the handler runs. Every violation is
+// reported, as domain.ValidationErrors wrapped in ErrInvalidPayload.
+// Routes are matched by their registered path with basePath stripped, so
+// the middleware must be installed on the group the document describes.
+func ValidateRequests(document *openapi.Document, basePath string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			path := strings.TrimPrefix(ctx.Path(), basePath)
+			schema := document.RequestSchema(ctx.Request().Method, path)
+			if schema == nil {
+				return next(ctx)
+			}
+
+			body, err := io.ReadAll(ctx.Request().Body)
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+			}
+			ctx.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			if err := validateBody(document, schema, body); err != nil {
+				return err
+			}
+			return next(ctx)
+		}
+	}
+}
+
+func validateBody(document *openapi.Document, schema *openapi.Schema, body []byte) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidPayload, domain.NewValidationError("", "required", "request body is required"))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return ErrInvalidPayload
+	}
+
+	violations := document.Validate(schema, value)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	errs := make(domain.ValidationErrors, 0, len(violations))
+	for _, violation := range violations {
+		errs = append(errs, domain.NewValidationError(violation.Path, violation.Code, violation.Message))
+	}
+	return fmt.Errorf("%w: %w", ErrInvalidPayload, errs)
+}
@@ -0,0 +1,132 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/dto"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestValidateRequests(t *testing.T) {
+	tests := []struct {
+		expectedViolations []dto.FieldViolation
+		testName           string
+		path               string
+		body               string
+		expectInvalid      bool
+	}{
+		{
+			testName: "Valid login",
+			path:     "/api/users/login",
+			body:     `{"email": "valid@user.com", "password": "unhashedPassword"}`,
+		},
+		{
+			testName:      "Unknown field and missing required fields",
+			path:          "/api/users/login",
+			body:          `{"invalid_field": "invalid_value"}`,
+			expectInvalid: true,
+			expectedViolations: []dto.FieldViolation{
+				{Field: "email", Code: "required", Detail: "is required"},
+				{Field: "password", Code: "required", Detail: "is required"},
+				{Field: "invalid_field", Code: "unknown_field", Detail: "is not a known field"},
+			},
+		},
+		{
+			testName:      "Wrong types",
+			path:          "/api/users/login",
+			body:          `{"email": 42, "password": null}`,
+			expectInvalid: true,
+			expectedViolations: []dto.FieldViolation{
+				{Field: "email", Code: "invalid_type", Detail: "must be a string"},
+				{Field: "password", Code: "invalid_type", Detail: "must be a string"},
+			},
+		},
+		{
+			testName:      "Unknown session mode",
+			path:          "/api/users/login",
+			body:          `{"email": "valid@user.com", "password": "unhashedPassword", "session": "jwt"}`,
+			expectInvalid: true,
+			expectedViolations: []dto.FieldViolation{
+				{Field: "session", Code: "invalid_value", Detail: "must be one of bearer, cookie"},
+			},
+		},
+		{
+			testName:      "Length limits",
+			path:          "/api/users/signup",
+			body:          `{"email": "valid@user.com", "username": "ab", "password": "` + strings.Repeat("a", 73) + `"}`,
+			expectInvalid: true,
+			expectedViolations: []dto.FieldViolation{
+				{Field: "password", Code: "too_long", Detail: "must be at most 72 characters long"},
+				{Field: "username", Code: "too_short", Detail: "must be at least 3 characters long"},
+			},
+		},
+		{
+			testName:      "Not an object",
+			path:          "/api/users/login",
+			body:          `["email"]`,
+			expectInvalid: true,
+			expectedViolations: []dto.FieldViolation{
+				{Field: "", Code: "invalid_type", Detail: "must be an object"},
+			},
+		},
+		{
+			testName:      "Empty body",
+			path:          "/api/users/login",
+			body:          ``,
+			expectInvalid: true,
+			expectedViolations: []dto.FieldViolation{
+				{Field: "", Code: "required", Detail: "request body is required"},
+			},
+		},
+		{
+			testName:      "Malformed JSON",
+			path:          "/api/users/login",
+			body:          `{"email": `,
+			expectInvalid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			app := setUpTestServer()
+			usersHandler, mockUsersService, mockTokenService := setUpDependencies(t)
+			docsHandler := api.NewDocsHandler(usersHandler)
+
+			var handlerErr error
+			app.HTTPErrorHandler = func(err error, ctx echo.Context) {
+				handlerErr = err
+			}
+
+			apiGroup := app.Group(api.APIBaseURL)
+			apiGroup.Use(api.ValidateRequests(docsHandler.Document(), api.APIBaseURL))
+			usersHandler.SetupRoutes(apiGroup)
+
+			if !tt.expectInvalid {
+				mockUsersService.EXPECT().
+					Login(mock.Anything, mock.Anything, mock.Anything).
+					Return(&domain.LoginResponse{}, nil)
+				mockTokenService.EXPECT().
+					Issue(mock.Anything).
+					Return(&domain.AccessToken{}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Add("Content-Type", "application/json")
+			app.ServeHTTP(httptest.NewRecorder(), req)
+
+			if !tt.expectInvalid {
+				assert.NoError(t, handlerErr)
+				return
+			}
+			assert.ErrorIs(t, handlerErr, api.ErrInvalidPayload)
+			assert.Equal(t, tt.expectedViolations, api.FieldViolations(handlerErr))
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package bulk_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/bulk"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAll(t *testing.T, reader ports.UserRecordReader) ([]*domain.ImportRecord, []*domain.ImportRowError) {
+	t.Helper()
+	var records []*domain.ImportRecord
+	var rowErrs []*domain.ImportRowError
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, rowErrs
+		}
+		var rowErr *domain.ImportRowError
+		if errors.As(err, &rowErr) {
+			rowErrs = append(rowErrs, rowErr)
+			continue
+		}
+		require.NoError(t, err)
+		records = append(records, record)
+	}
+}
+
+func TestCSVReader(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		expectedErr     error
+		expectedRecords []*domain.ImportRecord
+		expectedLines   []int
+	}{
+		{
+			name:  "All columns",
+			input: "email,username,password_hash,role\nfirst@user.com,first,,admin\nsecond@user.com, second ,hash,\n",
+			expectedRecords: []*domain.ImportRecord{
+				{Email: "first@user.com", Username: "first", Role: domain.RoleAdmin, Line: 2},
+				{Email: "second@user.com", Username: "second", PasswordHash: "hash", Line: 3},
+			},
+		},
+		{
+			name:  "Columns in any order",
+			input: "Username,Email\nfirst,first@user.com\n",
+			expectedRecords: []*domain.ImportRecord{
+				{Email: "first@user.com", Username: "first", Line: 2},
+			},
+		},
+		{
+			name:          "Malformed rows",
+			input:         "email,username\nfirst@user.com\n\"unterminated,x\n",
+			expectedLines: []int{2, 3},
+		},
+		{
+			name:        "Missing header",
+			input:       "",
+			expectedErr: bulk.ErrInvalidHeader,
+		},
+		{
+			name:        "Missing column",
+			input:       "email,password_hash\n",
+			expectedErr: bulk.ErrInvalidHeader,
+		},
+		{
+			name:        "Unknown column",
+			input:       "email,username,password\n",
+			expectedErr: bulk.ErrInvalidHeader,
+		},
+		{
+			name:        "Duplicate column",
+			input:       "email,username,email\n",
+			expectedErr: bulk.ErrInvalidHeader,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := bulk.NewCSVReader(strings.NewReader(tt.input))
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			records, rowErrs := readAll(t, reader)
+			assert.Equal(t, tt.expectedRecords, records)
+			var lines []int
+			for _, rowErr := range rowErrs {
+				lines = append(lines, rowErr.Line)
+			}
+			assert.Equal(t, tt.expectedLines, lines)
+		})
+	}
+}
+
+func TestJSONLReader(t *testing.T) {
+	input := `{"email":"first@user.com","username":"first","role":"admin"}
+
+{"email":"second@user.com","password":"plain"}
+{"email":"third@user.com",
+{"email":"fourth@user.com","username":"fourth","password_hash":"hash"}
+`
+	records, rowErrs := readAll(t, bulk.NewJSONLReader(strings.NewReader(input)))
+
+	assert.Equal(t, []*domain.ImportRecord{
+		{Email: "first@user.com", Username: "first", Role: domain.RoleAdmin, Line: 1},
+		{Email: "fourth@user.com", Username: "fourth", PasswordHash: "hash", Line: 5},
+	}, records)
+	require.Len(t, rowErrs, 2)
+	assert.Equal(t, 3, rowErrs[0].Line)
+	assert.Equal(t, 4, rowErrs[1].Line)
+	assert.ErrorIs(t, rowErrs[0], bulk.ErrMalformedRecord)
+}
+
+func TestWriters(t *testing.T) {
+	users := []*domain.UserProfile{
+		{ID: 1, Email: "first@user.com", Username: "first", Role: domain.RoleAdmin},
+		{ID: 2, Email: "second@user.com", Username: "second", Role: domain.RoleUser, Disabled: true},
+	}
+
+	tests := []struct {
+		name      string
+		newWriter func(w io.Writer) ports.UserRecordWriter
+		users     []*domain.UserProfile
+		expected  string
+	}{
+		{
+			name:      "CSV",
+			newWriter: func(w io.Writer) ports.UserRecordWriter { return bulk.NewCSVWriter(w) },
+			users:     users,
+			expected:  "id,email,username,role,disabled\n1,first@user.com,first,admin,false\n2,second@user.com,second,user,true\n",
+		},
+		{
+			name:      "CSV without users",
+			newWriter: func(w io.Writer) ports.UserRecordWriter { return bulk.NewCSVWriter(w) },
+			expected:  "id,email,username,role,disabled\n",
+		},
+		{
+			name:      "JSON Lines",
+			newWriter: func(w io.Writer) ports.UserRecordWriter { return bulk.NewJSONLWriter(w) },
+			users:     users,
+			expected: `{"email":"first@user.com","username":"first","role":"admin","id":1,"disabled":false}
+{"email":"second@user.com","username":"second","role":"user","id":2,"disabled":true}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writer := tt.newWriter(&buf)
+			for _, user := range tt.users {
+				require.NoError(t, writer.Write(user))
+			}
+			require.NoError(t, writer.Flush())
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	writer := bulk.NewCSVWriter(&buf)
+	require.NoError(t, writer.Write(&domain.UserProfile{ID: 1, Email: "first@user.com", Username: "first", Role: domain.RoleUser}))
+	require.NoError(t, writer.Flush())
+
+	reader, err := bulk.NewCSVReader(&buf)
+	require.NoError(t, err)
+	records, rowErrs := readAll(t, reader)
+	assert.Empty(t, rowErrs)
+	assert.Equal(t, []*domain.ImportRecord{
+		{Email: "first@user.com", Username: "first", Role: domain.RoleUser, Line: 2},
+	}, records)
+}
+
+func TestListInviter(t *testing.T) {
+	var buf bytes.Buffer
+	inviter := bulk.NewListInviter(&buf)
+
+	err := inviter.Invite(context.Background(), &domain.UserProfile{Email: "first@user.com", Username: "first"})
+	require.NoError(t, err)
+	assert.Equal(t, "{\"email\":\"first@user.com\",\"username\":\"first\"}\n", buf.String())
+}
@@ -0,0 +1,147 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+)
+
+const (
+	columnEmail        = "email"
+	columnUsername     = "username"
+	columnPasswordHash = "password_hash"
+	columnRole         = "role"
+	columnID           = "id"
+	columnDisabled     = "disabled"
+)
+
+var (
+	requiredColumns = []string{columnEmail, columnUsername}
+	knownColumns    = []string{columnEmail, columnUsername, columnPasswordHash, columnRole}
+	exportColumns   = []string{columnID, columnEmail, columnUsername, columnRole, columnDisabled}
+)
+
+type CSVReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+	fields  int
+}
+
+// NewCSVReader reads the header row and checks that it names the email and
+// username columns. password_hash and role are optional, and the id and
+// disabled columns of an export are ignored so that it can be imported back.
+// Any other column is rejected so that a typo does not silently drop data.
+func NewCSVReader(r io.Reader) (*CSVReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: missing header row", ErrInvalidHeader)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == columnID || name == columnDisabled {
+			continue
+		}
+		if !slices.Contains(knownColumns, name) {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidHeader, name)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("%w: duplicate column %q", ErrInvalidHeader, name)
+		}
+		columns[name] = i
+	}
+	for _, name := range requiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidHeader, name)
+		}
+	}
+
+	return &CSVReader{reader: reader, columns: columns, fields: len(header)}, nil
+}
+
+func (r *CSVReader) Read() (*domain.ImportRecord, error) {
+	fields, err := r.reader.Read()
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return nil, &domain.ImportRowError{Line: parseErr.StartLine, Err: parseErr.Err}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	line, _ := r.reader.FieldPos(0)
+	if len(fields) != r.fields {
+		return nil, &domain.ImportRowError{
+			Line: line,
+			Err:  fmt.Errorf("%w: expected %d fields, got %d", ErrMalformedRecord, r.fields, len(fields)),
+		}
+	}
+
+	return &domain.ImportRecord{
+		Email:        r.field(fields, columnEmail),
+		Username:     r.field(fields, columnUsername),
+		PasswordHash: r.field(fields, columnPasswordHash),
+		Role:         domain.Role(r.field(fields, columnRole)),
+		Line:         line,
+	}, nil
+}
+
+func (r *CSVReader) field(fields []string, column string) string {
+	i, ok := r.columns[column]
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(fields[i])
+}
+
+type CSVWriter struct {
+	writer      *csv.Writer
+	wroteHeader bool
+}
+
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{writer: csv.NewWriter(w)}
+}
+
+func (w *CSVWriter) Write(user *domain.UserProfile) error {
+	if !w.wroteHeader {
+		if err := w.writer.Write(exportColumns); err != nil {
+			return err
+		}
+		w.wroteHeader = true
+	}
+	return w.writer.Write([]string{
+		strconv.FormatUint(uint64(user.ID), 10),
+		user.Email,
+		user.Username,
+		string(user.Role),
+		strconv.FormatBool(user.Disabled),
+	})
+}
+
+// Flush writes the header even when no user was exported, so that the file
+// can be imported back.
+func (w *CSVWriter) Flush() error {
+	if !w.wroteHeader {
+		if err := w.writer.Write(exportColumns); err != nil {
+			return err
+		}
+		w.wroteHeader = true
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
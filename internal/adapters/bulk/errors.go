@@ -0,0 +1,8 @@
+package bulk
+
+import "errors"
+
+var (
+	ErrInvalidHeader   = errors.New("invalid header")
+	ErrMalformedRecord = errors.New("malformed record")
+)
@@ -0,0 +1,33 @@
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+)
+
+// ListInviter appends one JSON object per invited user to w, for a mailer
+// outside of this service to pick up.
+type ListInviter struct {
+	encoder *json.Encoder
+	mu      sync.Mutex
+}
+
+func NewListInviter(w io.Writer) *ListInviter {
+	return &ListInviter{encoder: json.NewEncoder(w)}
+}
+
+func (i *ListInviter) Invite(ctx context.Context, user *domain.UserProfile) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.encoder.Encode(struct {
+		Email    string `json:"email"`
+		Username string `json:"username"`
+	}{
+		Email:    user.Email,
+		Username: user.Username,
+	})
+}
@@ -1,6 +1,36 @@
 package dto
 
 type ErrorResponse struct {
-	Message    string `json:"message"`
-	StatusCode int    `json:"status_code"`
+	Message    string `json:"message" schema:"required"`
+	Code       string `json:"code" schema:"required"`
+	Field      string `json:"field,omitempty"`
+	RequestID  string `json:"request_id,omitempty"`
+	StatusCode int    `json:"status_code" schema:"required"`
+}
+
+type ProblemDetails struct {
+	Type      string           `json:"type" schema:"required,format=uri-reference"`
+	Title     string           `json:"title" schema:"required"`
+	Detail    string           `json:"detail,omitempty"`
+	Instance  string           `json:"instance,omitempty" schema:"format=uri-reference"`
+	Code      string           `json:"code" schema:"required"`
+	Errors    []FieldViolation `json:"errors,omitempty"`
+	RequestID string           `json:"request_id,omitempty"`
+	Status    int              `json:"status" schema:"required"`
+}
+
+type FieldViolation struct {
+	Field  string `json:"field" schema:"required"`
+	Code   string `json:"code" schema:"required"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type HealthResponse struct {
+	Status     string                     `json:"status" schema:"required"`
+	Components map[string]ComponentHealth `json:"components,omitempty"`
+}
+
+type ComponentHealth struct {
+	Status string `json:"status" schema:"required"`
+	Error  string `json:"error,omitempty"`
 }
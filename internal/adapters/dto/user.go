@@ -3,36 +3,48 @@ package dto
 import "github.com/raphael-foliveira/go-table-tests/internal/core/domain"
 
 type SignupPayload struct {
-	Email    string `json:"email"`
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Email    string `json:"email" schema:"required,format=email,maxLength=254"`
+	Username string `json:"username" schema:"required,minLength=3,maxLength=32"`
+	Password string `json:"password" schema:"required,minLength=6,maxLength=72"`
 }
 
 type SignupResponse struct {
-	Email    string `json:"email"`
-	Username string `json:"username"`
-	ID       uint   `json:"id"`
+	Email    string `json:"email" schema:"required,format=email"`
+	Username string `json:"username" schema:"required"`
+	ID       uint   `json:"id" schema:"required"`
 }
 
 type LoginPayload struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string `json:"email" schema:"required,format=email,maxLength=254"`
+	Password string `json:"password" schema:"required,maxLength=72"`
+	// Session is "bearer", the default, or "cookie".
+	Session string `json:"session,omitempty" schema:"enum=bearer|cookie"`
 }
 
+// LoginResponse carries the access token of bearer sessions, or the CSRF
+// token of cookie sessions, whose access token is in the session cookie.
 type LoginResponse struct {
-	Email    string `json:"email"`
-	Username string `json:"username"`
+	Email       string `json:"email" schema:"required,format=email"`
+	Username    string `json:"username" schema:"required"`
+	AccessToken string `json:"access_token,omitempty"`
+	TokenType   string `json:"token_type,omitempty"`
+	CSRFToken   string `json:"csrf_token,omitempty"`
+	ExpiresIn   int    `json:"expires_in" schema:"required"`
 }
 
 type User struct {
-	Username string `db:"username"`
-	Email    string `db:"email"`
-	Password string `db:"password"`
-	ID       uint   `db:"id"`
+	Username         string `db:"username"`
+	UsernameSkeleton string `db:"username_skeleton"`
+	Email            string `db:"email"`
+	Password         string `db:"password"`
+	Role             string `db:"role"`
+	ID               uint   `db:"id"`
+	Disabled         bool   `db:"disabled"`
 }
 
 func (u *User) ToDomainUser() *domain.User {
 	return &domain.User{
+		ID:       u.ID,
 		Username: u.Username,
 		Email: &domain.Email{
 			Value: u.Email,
@@ -41,5 +53,7 @@ func (u *User) ToDomainUser() *domain.User {
 			Value:    u.Password,
 			IsHashed: true,
 		},
+		Role:     domain.Role(u.Role),
+		Disabled: u.Disabled,
 	}
 }
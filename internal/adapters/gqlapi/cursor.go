@@ -0,0 +1,39 @@
+package gqlapi
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const cursorPrefix = "user:"
+
+// Cursors are opaque to clients; they wrap the ID of the last user of a page.
+func encodeCursor(id uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.FormatUint(uint64(id), 10)))
+}
+
+func decodeCursor(cursor *string) (uint, error) {
+	if cursor == nil {
+		return 0, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(*cursor)
+	if err != nil || !strings.HasPrefix(string(decoded), cursorPrefix) {
+		return 0, errInvalidCursor()
+	}
+	id, err := strconv.ParseUint(strings.TrimPrefix(string(decoded), cursorPrefix), 10, 0)
+	if err != nil {
+		return 0, errInvalidCursor()
+	}
+	return uint(id), nil
+}
+
+func errInvalidCursor() *gqlerror.Error {
+	return &gqlerror.Error{
+		Message:    "invalid cursor",
+		Extensions: map[string]any{"code": "invalid_cursor"},
+	}
+}
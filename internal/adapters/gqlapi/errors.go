@@ -0,0 +1,48 @@
+package gqlapi
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// errorPresenter plays the part of the HTTP server's error handler. Errors
+// raised by the GraphQL engine itself, such as parse, validation and limit
+// errors, are passed through. Resolver errors are looked up in the api error
+// registry; unknown ones are logged and reported without their message.
+// Resolver errors also carry the request ID in a "request_id" extension.
+func errorPresenter(logger *slog.Logger) graphql.ErrorPresenterFunc {
+	return func(ctx context.Context, err error) *gqlerror.Error {
+		presented := graphql.DefaultErrorPresenter(ctx, err)
+		if presented.Err == nil {
+			return presented
+		}
+
+		mapping, ok := api.LookupError(presented.Err)
+		if !ok {
+			logger.ErrorContext(ctx, "graphql resolver failed",
+				slog.String("path", presented.Path.String()),
+				slog.Any("error", presented.Err),
+			)
+			presented.Message = "internal server error"
+			presented.Extensions = map[string]any{"code": "internal_error"}
+		} else {
+			presented.Message = mapping.Message
+			presented.Extensions = map[string]any{"code": mapping.Code}
+			if violations := api.FieldViolations(presented.Err); len(violations) > 0 {
+				presented.Extensions["violations"] = violations
+			} else if mapping.Field != "" {
+				presented.Extensions["field"] = mapping.Field
+			}
+		}
+
+		if requestID := logging.RequestIDFromContext(ctx); requestID != "" {
+			presented.Extensions[logging.RequestIDKey] = requestID
+		}
+		return presented
+	}
+}
@@ -0,0 +1,76 @@
+// Package gqlapi serves ports.UsersService over GraphQL. Resolvers return
+// service errors unchanged; presentError maps them onto the codes of the
+// api error registry, reported in each error's "code" extension.
+package gqlapi
+
+import (
+	"log/slog"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/labstack/echo/v4"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
+)
+
+const Path = "/graphql"
+
+// Limits bounds the cost of a single operation. Depth ignores introspection
+// fields; complexity counts one per field, multiplied by the page size of
+// list fields.
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+var DefaultLimits = Limits{
+	MaxDepth:      10,
+	MaxComplexity: 1000,
+}
+
+type Resolver struct {
+	usersService ports.UsersService
+	tokens       ports.TokenService
+}
+
+type Handler struct {
+	server *handler.Server
+}
+
+func NewHandler(usersService ports.UsersService, tokens ports.TokenService, limits Limits, logger *slog.Logger) *Handler {
+	config := Config{
+		Resolvers: &Resolver{
+			usersService: usersService,
+			tokens:       tokens,
+		},
+	}
+	config.Complexity.Query.Users = func(childComplexity int, first *int, _ *string) int {
+		return childComplexity * pageSize(first)
+	}
+
+	server := handler.New(NewExecutableSchema(config))
+	server.AddTransport(transport.GET{})
+	server.AddTransport(transport.POST{})
+	server.Use(extension.Introspection{})
+	server.Use(DepthLimit{MaxDepth: limits.MaxDepth})
+	server.Use(extension.FixedComplexityLimit(limits.MaxComplexity))
+	server.SetErrorPresenter(errorPresenter(logger))
+
+	return &Handler{
+		server: server,
+	}
+}
+
+func (h *Handler) SetupRoutes(group *echo.Group) {
+	group.GET(Path, echo.WrapHandler(h.server))
+	group.POST(Path, echo.WrapHandler(h.server))
+}
+
+// pageSize mirrors the clamping done by service.Users.List.
+func pageSize(first *int) int {
+	if first == nil || *first <= 0 {
+		return service.DefaultPageSize
+	}
+	return min(*first, service.MaxPageSize)
+}
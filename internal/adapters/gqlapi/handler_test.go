@@ -0,0 +1,346 @@
+package gqlapi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/gqlapi"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/security"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"github.com/raphael-foliveira/go-table-tests/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type graphQLResponse struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Extensions map[string]any `json:"extensions"`
+		Message    string         `json:"message"`
+	} `json:"errors"`
+}
+
+func setUpTokens(t *testing.T) *security.Tokens {
+	tokens, err := security.NewTokens([]byte(strings.Repeat("k", security.MinSigningKeyLength)), 15*time.Minute)
+	require.NoError(t, err)
+	return tokens
+}
+
+func setUpTestServer(t *testing.T, principal *domain.Principal, limits gqlapi.Limits) (*echo.Echo, *mocks.MockUsersService) {
+	mockUsersService := mocks.NewMockUsersService(t)
+
+	app := echo.New()
+	apiGroup := app.Group("/api")
+	if principal != nil {
+		apiGroup.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(ctx echo.Context) error {
+				req := ctx.Request()
+				ctx.SetRequest(req.WithContext(domain.ContextWithPrincipal(req.Context(), principal)))
+				return next(ctx)
+			}
+		})
+	}
+	gqlapi.NewHandler(mockUsersService, setUpTokens(t), limits, logging.Discard()).SetupRoutes(apiGroup)
+	return app, mockUsersService
+}
+
+func execute(t *testing.T, app *echo.Echo, query string, variables map[string]any) *graphQLResponse {
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	app.ServeHTTP(recorder, req)
+
+	var response *graphQLResponse
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&response))
+	return response
+}
+
+func TestGraphQL_Login(t *testing.T) {
+	app, mockUsersService := setUpTestServer(t, nil, gqlapi.DefaultLimits)
+
+	mockUsersService.EXPECT().
+		Login(mock.Anything, "valid@user.com", "unhashedPassword").
+		Return(&domain.LoginResponse{Email: "valid@user.com", Username: "validuser", Role: domain.RoleUser, UserID: 7}, nil)
+
+	response := execute(t, app, `mutation($input: LoginInput!) { login(input: $input) { email username accessToken tokenType expiresIn } }`, map[string]any{
+		"input": map[string]any{"email": "valid@user.com", "password": "unhashedPassword"},
+	})
+
+	assert.Empty(t, response.Errors)
+	var login struct {
+		Email       string `json:"email"`
+		Username    string `json:"username"`
+		AccessToken string `json:"accessToken"`
+		TokenType   string `json:"tokenType"`
+		ExpiresIn   int    `json:"expiresIn"`
+	}
+	require.NoError(t, json.Unmarshal(response.Data["login"], &login))
+	assert.Equal(t, "valid@user.com", login.Email)
+	assert.Equal(t, "validuser", login.Username)
+	assert.Equal(t, "Bearer", login.TokenType)
+	assert.InDelta(t, 15*60, login.ExpiresIn, 1)
+
+	principal, err := setUpTokens(t).Verify(login.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, &domain.Principal{Role: domain.RoleUser, UserID: 7}, principal)
+}
+
+func TestGraphQL_Signup(t *testing.T) {
+	app, mockUsersService := setUpTestServer(t, nil, gqlapi.DefaultLimits)
+
+	mockUsersService.EXPECT().
+		Signup(mock.Anything, &domain.SignupPayload{Email: "valid@user.com", Username: "validuser", Password: "unhashedPassword"}).
+		Return(&domain.SignupResponse{ID: 1, Email: "valid@user.com", Username: "validuser", Role: domain.RoleUser}, nil)
+
+	response := execute(t, app, `mutation($input: SignupInput!) { signup(input: $input) { id email username role } }`, map[string]any{
+		"input": map[string]any{"email": "valid@user.com", "username": "validuser", "password": "unhashedPassword"},
+	})
+
+	assert.Empty(t, response.Errors)
+	assert.JSONEq(t, `{"id": "1", "email": "valid@user.com", "username": "validuser", "role": "USER"}`, string(response.Data["signup"]))
+}
+
+func TestGraphQL_ErrorExtensions(t *testing.T) {
+	tests := []struct {
+		serviceErr         error
+		expectedExtensions map[string]any
+		testName           string
+		expectedMessage    string
+	}{
+		{
+			testName:           "Conflict",
+			serviceErr:         service.ErrEmailAlreadyTaken,
+			expectedMessage:    "email already taken",
+			expectedExtensions: map[string]any{"code": "email_taken", "field": "email"},
+		},
+		{
+			testName: "Validation errors",
+			serviceErr: fmt.Errorf("%w: %w", service.ErrInvalidUserPayload, domain.ValidationErrors{
+				domain.ErrPasswordTooShort,
+				domain.ErrUsernameTooShort,
+			}),
+			expectedMessage: "validation failed",
+			expectedExtensions: map[string]any{
+				"code": "validation_failed",
+				"violations": []any{
+					map[string]any{"field": "password", "code": "too_short", "detail": "password is too short"},
+					map[string]any{"field": "username", "code": "too_short", "detail": "username is too short"},
+				},
+			},
+		},
+		{
+			testName:           "Unknown error",
+			serviceErr:         errors.New("connection refused"),
+			expectedMessage:    "internal server error",
+			expectedExtensions: map[string]any{"code": "internal_error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			app, mockUsersService := setUpTestServer(t, nil, gqlapi.DefaultLimits)
+
+			mockUsersService.EXPECT().
+				Signup(mock.Anything, mock.Anything).
+				Return(nil, tt.serviceErr)
+
+			response := execute(t, app, `mutation { signup(input: {email: "", username: "", password: ""}) { id } }`, nil)
+
+			require.Len(t, response.Errors, 1)
+			assert.Equal(t, tt.expectedMessage, response.Errors[0].Message)
+			assert.Equal(t, tt.expectedExtensions, response.Errors[0].Extensions)
+		})
+	}
+}
+
+func TestGraphQL_ErrorRequestID(t *testing.T) {
+	mockUsersService := mocks.NewMockUsersService(t)
+	app := echo.New()
+	apiGroup := app.Group("/api")
+	apiGroup.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			req := ctx.Request()
+			ctx.SetRequest(req.WithContext(logging.ContextWithRequestID(req.Context(), "test-request-id")))
+			return next(ctx)
+		}
+	})
+	gqlapi.NewHandler(mockUsersService, setUpTokens(t), gqlapi.DefaultLimits, logging.Discard()).SetupRoutes(apiGroup)
+
+	mockUsersService.EXPECT().
+		Login(mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("connection refused"))
+
+	response := execute(t, app, `mutation { login(input: {email: "valid@user.com", password: "unhashedPassword"}) { email } }`, nil)
+
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, map[string]any{"code": "internal_error", "request_id": "test-request-id"}, response.Errors[0].Extensions)
+}
+
+func TestGraphQL_Me(t *testing.T) {
+	principal := &domain.Principal{UserID: 1, Role: domain.RoleUser}
+	app, mockUsersService := setUpTestServer(t, principal, gqlapi.DefaultLimits)
+
+	mockUsersService.EXPECT().
+		Me(mock.MatchedBy(func(ctx context.Context) bool {
+			found, ok := domain.PrincipalFromContext(ctx)
+			return ok && found == principal
+		})).
+		Return(&domain.UserProfile{ID: 1, Email: "valid@user.com", Username: "validuser", Role: domain.RoleUser}, nil)
+
+	response := execute(t, app, `{ me { id username role } }`, nil)
+
+	assert.Empty(t, response.Errors)
+	assert.JSONEq(t, `{"id": "1", "username": "validuser", "role": "USER"}`, string(response.Data["me"]))
+}
+
+func TestGraphQL_Users(t *testing.T) {
+	app, mockUsersService := setUpTestServer(t, &domain.Principal{UserID: 1, Role: domain.RoleAdmin}, gqlapi.DefaultLimits)
+	query := `query($after: String) {
+		users(first: 2, after: $after) {
+			edges { cursor node { id } }
+			pageInfo { hasNextPage endCursor }
+		}
+	}`
+
+	mockUsersService.EXPECT().
+		List(mock.Anything, domain.ListUsersQuery{First: 2}).
+		Return(&domain.UsersPage{
+			Users:       []*domain.UserProfile{{ID: 1, Role: domain.RoleAdmin}, {ID: 2, Role: domain.RoleUser}},
+			HasNextPage: true,
+		}, nil)
+
+	first := execute(t, app, query, nil)
+	require.Empty(t, first.Errors)
+
+	var connection struct {
+		Edges []struct {
+			Cursor string `json:"cursor"`
+			Node   struct {
+				ID string `json:"id"`
+			} `json:"node"`
+		} `json:"edges"`
+		PageInfo struct {
+			EndCursor   string `json:"endCursor"`
+			HasNextPage bool   `json:"hasNextPage"`
+		} `json:"pageInfo"`
+	}
+	require.NoError(t, json.Unmarshal(first.Data["users"], &connection))
+	require.Len(t, connection.Edges, 2)
+	assert.Equal(t, "2", connection.Edges[1].Node.ID)
+	assert.True(t, connection.PageInfo.HasNextPage)
+	assert.Equal(t, connection.Edges[1].Cursor, connection.PageInfo.EndCursor)
+
+	mockUsersService.EXPECT().
+		List(mock.Anything, domain.ListUsersQuery{First: 2, After: 2}).
+		Return(&domain.UsersPage{}, nil)
+
+	second := execute(t, app, query, map[string]any{"after": connection.PageInfo.EndCursor})
+	assert.Empty(t, second.Errors)
+	assert.JSONEq(t, `{"edges": [], "pageInfo": {"hasNextPage": false, "endCursor": null}}`, string(second.Data["users"]))
+}
+
+func TestGraphQL_UsersErrors(t *testing.T) {
+	tests := []struct {
+		serviceErr   error
+		testName     string
+		after        string
+		expectedCode string
+	}{
+		{
+			testName:     "Invalid cursor",
+			after:        "not-a-cursor",
+			expectedCode: "invalid_cursor",
+		},
+		{
+			testName:     "Not an admin",
+			serviceErr:   service.ErrForbidden,
+			expectedCode: "forbidden",
+		},
+		{
+			testName:     "Unauthenticated",
+			serviceErr:   service.ErrUnauthenticated,
+			expectedCode: "unauthenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			app, mockUsersService := setUpTestServer(t, nil, gqlapi.DefaultLimits)
+
+			variables := map[string]any{}
+			if tt.after != "" {
+				variables["after"] = tt.after
+			}
+			if tt.serviceErr != nil {
+				mockUsersService.EXPECT().List(mock.Anything, mock.Anything).Return(nil, tt.serviceErr)
+			}
+
+			response := execute(t, app, `query($after: String) { users(after: $after) { edges { cursor } } }`, variables)
+
+			require.Len(t, response.Errors, 1)
+			assert.Equal(t, tt.expectedCode, response.Errors[0].Extensions["code"])
+		})
+	}
+}
+
+func TestGraphQL_Limits(t *testing.T) {
+	tests := []struct {
+		limits       gqlapi.Limits
+		testName     string
+		query        string
+		expectedCode string
+	}{
+		{
+			testName:     "Depth",
+			limits:       gqlapi.Limits{MaxDepth: 2, MaxComplexity: 1000},
+			query:        `{ users { edges { node { id } } } }`,
+			expectedCode: "DEPTH_LIMIT_EXCEEDED",
+		},
+		{
+			testName:     "Depth through fragments",
+			limits:       gqlapi.Limits{MaxDepth: 3, MaxComplexity: 1000},
+			query:        `{ users { ...page } } fragment page on UserConnection { edges { node { id } } }`,
+			expectedCode: "DEPTH_LIMIT_EXCEEDED",
+		},
+		{
+			testName:     "Complexity",
+			limits:       gqlapi.Limits{MaxDepth: 10, MaxComplexity: 100},
+			query:        `{ users(first: 100) { edges { node { id email } } } }`,
+			expectedCode: "COMPLEXITY_LIMIT_EXCEEDED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			app, _ := setUpTestServer(t, nil, tt.limits)
+
+			response := execute(t, app, tt.query, nil)
+
+			require.Len(t, response.Errors, 1)
+			assert.Equal(t, tt.expectedCode, response.Errors[0].Extensions["code"])
+		})
+	}
+}
+
+func TestGraphQL_IntrospectionIgnoresDepthLimit(t *testing.T) {
+	app, _ := setUpTestServer(t, nil, gqlapi.Limits{MaxDepth: 1, MaxComplexity: 1000})
+
+	response := execute(t, app, `{ __schema { types { fields { type { ofType { ofType { name } } } } } } }`, nil)
+
+	assert.Empty(t, response.Errors)
+}
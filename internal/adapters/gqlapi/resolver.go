@@ -0,0 +1,110 @@
+package gqlapi
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+)
+
+// Login is the resolver for the login field. GraphQL clients authenticate
+// with bearer tokens only; cookie sessions are started by the REST login.
+func (r *mutationResolver) Login(ctx context.Context, input LoginInput) (*LoginResult, error) {
+	loginResponse, err := r.usersService.Login(ctx, input.Email, input.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := r.tokens.Issue(&domain.Principal{
+		Role:   loginResponse.Role,
+		UserID: loginResponse.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginResult{
+		Email:       loginResponse.Email,
+		Username:    loginResponse.Username,
+		AccessToken: token.Value,
+		TokenType:   api.TokenTypeBearer,
+		ExpiresIn:   int(time.Until(token.ExpiresAt).Round(time.Second).Seconds()),
+	}, nil
+}
+
+// Signup is the resolver for the signup field.
+func (r *mutationResolver) Signup(ctx context.Context, input SignupInput) (*User, error) {
+	signupResponse, err := r.usersService.Signup(ctx, &domain.SignupPayload{
+		Email:    input.Email,
+		Username: input.Username,
+		Password: input.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newUser(&domain.UserProfile{
+		ID:       signupResponse.ID,
+		Email:    signupResponse.Email,
+		Username: signupResponse.Username,
+		Role:     signupResponse.Role,
+	}), nil
+}
+
+// Me is the resolver for the me field.
+func (r *queryResolver) Me(ctx context.Context) (*User, error) {
+	profile, err := r.usersService.Me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newUser(profile), nil
+}
+
+// Users is the resolver for the users field.
+func (r *queryResolver) Users(ctx context.Context, first *int, after *string) (*UserConnection, error) {
+	afterID, err := decodeCursor(after)
+	if err != nil {
+		return nil, err
+	}
+
+	query := domain.ListUsersQuery{After: afterID}
+	if first != nil {
+		query.First = *first
+	}
+	page, err := r.usersService.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	connection := &UserConnection{
+		Edges:    []*UserEdge{},
+		PageInfo: &PageInfo{HasNextPage: page.HasNextPage},
+	}
+	for _, profile := range page.Users {
+		cursor := encodeCursor(profile.ID)
+		connection.Edges = append(connection.Edges, &UserEdge{Cursor: cursor, Node: newUser(profile)})
+		connection.PageInfo.EndCursor = &cursor
+	}
+	return connection, nil
+}
+
+// Mutation returns MutationResolver implementation.
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+type mutationResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+
+func newUser(profile *domain.UserProfile) *User {
+	return &User{
+		ID:       strconv.FormatUint(uint64(profile.ID), 10),
+		Email:    profile.Email,
+		Username: profile.Username,
+		Role:     Role(strings.ToUpper(string(profile.Role))),
+	}
+}
@@ -0,0 +1,98 @@
+package grpcapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain identifies this service in google.rpc.ErrorInfo details.
+const ErrorDomain = "users"
+
+// statusCodes translates the HTTP statuses of the api error registry, so
+// both transports classify a domain error the same way.
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusInternalServerError: codes.Internal,
+}
+
+// ToStatus converts err into a gRPC status error. Errors known to the api
+// error registry keep their code as the reason of an ErrorInfo detail, and
+// field violations are listed in a BadRequest detail. Anything else becomes
+// Internal without exposing its message, with a RequestInfo detail so that
+// the client can quote the request ID. Status errors pass through.
+func ToStatus(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	requestID := logging.RequestIDFromContext(ctx)
+	mapping, ok := api.LookupError(err)
+	if !ok {
+		st := status.New(codes.Internal, "internal server error")
+		if requestID == "" {
+			return st.Err()
+		}
+		withDetails, detailsErr := st.WithDetails(&errdetails.RequestInfo{RequestId: requestID})
+		if detailsErr != nil {
+			return st.Err()
+		}
+		return withDetails.Err()
+	}
+
+	code, ok := statusCodes[mapping.StatusCode]
+	if !ok {
+		code = codes.Unknown
+	}
+
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{Reason: mapping.Code, Domain: ErrorDomain},
+	}
+	if requestID != "" {
+		details = append(details, &errdetails.RequestInfo{RequestId: requestID})
+	}
+	if badRequest := badRequestDetails(err, mapping); badRequest != nil {
+		details = append(details, badRequest)
+	}
+
+	st, detailsErr := status.New(code, mapping.Message).WithDetails(details...)
+	if detailsErr != nil {
+		return status.Error(code, mapping.Message)
+	}
+	return st.Err()
+}
+
+func badRequestDetails(err error, mapping api.ErrorMapping) *errdetails.BadRequest {
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range api.FieldViolations(err) {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Detail,
+		})
+	}
+	if len(badRequest.FieldViolations) == 0 && mapping.Field != "" {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       mapping.Field,
+			Description: mapping.Message,
+		})
+	}
+
+	if len(badRequest.FieldViolations) == 0 {
+		return nil
+	}
+	return badRequest
+}
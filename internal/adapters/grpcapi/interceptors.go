@@ -0,0 +1,129 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDMetadataKey is logging.RequestIDHeader as gRPC metadata keys
+// are lowercase.
+const requestIDMetadataKey = "x-request-id"
+
+// RequestIDInterceptor attaches the caller's x-request-id metadata, or a
+// generated ID, to the context and returns it in the response headers.
+func RequestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var id string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(requestIDMetadataKey); len(values) > 0 {
+				id = values[0]
+			}
+		}
+		id = logging.ResolveRequestID(id)
+
+		grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadataKey, id))
+		return handler(logging.ContextWithRequestID(ctx, id), req)
+	}
+}
+
+// RecoveryInterceptor turns a panic in a handler into an Internal status,
+// logging the panic and its stack, so that one call cannot take the server
+// down.
+func RecoveryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.ErrorContext(ctx, "handler panicked",
+					slog.String("method", info.FullMethod),
+					slog.Any("panic", r),
+					slog.String("stack", string(debug.Stack())),
+				)
+				resp, err = nil, ErrInternal
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// ErrorInterceptor converts handler errors with ToStatus. It plays the part
+// of the HTTP server's error handler and logs the errors that end up as
+// Internal, since their message is not sent to the client.
+func ErrorInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		converted := ToStatus(ctx, err)
+		if status.Code(converted) == codes.Internal {
+			logger.ErrorContext(ctx, "call failed",
+				slog.String("method", info.FullMethod),
+				slog.Any("error", err),
+			)
+		}
+		return nil, converted
+	}
+}
+
+// LoggingInterceptor logs the method, status code and duration of every call.
+func LoggingInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logger.InfoContext(ctx, "call",
+			slog.String("method", info.FullMethod),
+			slog.String("code", status.Code(err).String()),
+			slog.Duration("duration", time.Since(start)),
+		)
+		return resp, err
+	}
+}
+
+// APIKeyInterceptor admits calls whose "authorization" metadata holds
+// "Bearer <key>" for one of keys. Calling services are trusted as a whole;
+// there is no per-user identity on this transport.
+func APIKeyInterceptor(keys []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !authorized(ctx, keys) {
+			return nil, ErrUnauthenticated
+		}
+		return handler(ctx, req)
+	}
+}
+
+func authorized(ctx context.Context, keys []string) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	for _, value := range md.Get("authorization") {
+		scheme, token, found := strings.Cut(value, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") {
+			continue
+		}
+		for _, key := range keys {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(key)) == 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var (
+	ErrUnauthenticated = status.Error(codes.Unauthenticated, "missing or invalid API key")
+	ErrInternal        = status.Error(codes.Internal, "internal server error")
+)
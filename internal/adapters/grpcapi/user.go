@@ -0,0 +1,51 @@
+// Package grpcapi serves ports.UsersService over gRPC. Handlers return
+// service errors unchanged; ErrorInterceptor turns them into statuses.
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+	usersv1 "github.com/raphael-foliveira/go-table-tests/pkg/proto/users/v1"
+)
+
+type UsersServer struct {
+	usersv1.UnimplementedUsersServiceServer
+	usersService ports.UsersService
+}
+
+func NewUsersServer(usersService ports.UsersService) *UsersServer {
+	return &UsersServer{
+		usersService: usersService,
+	}
+}
+
+func (s *UsersServer) Login(ctx context.Context, req *usersv1.LoginRequest) (*usersv1.LoginResponse, error) {
+	loginResponse, err := s.usersService.Login(ctx, req.GetEmail(), req.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+
+	return &usersv1.LoginResponse{
+		Email:    loginResponse.Email,
+		Username: loginResponse.Username,
+	}, nil
+}
+
+func (s *UsersServer) Signup(ctx context.Context, req *usersv1.SignupRequest) (*usersv1.SignupResponse, error) {
+	signupResponse, err := s.usersService.Signup(ctx, &domain.SignupPayload{
+		Email:    req.GetEmail(),
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &usersv1.SignupResponse{
+		Id:       uint64(signupResponse.ID),
+		Email:    signupResponse.Email,
+		Username: signupResponse.Username,
+	}, nil
+}
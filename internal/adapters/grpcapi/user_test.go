@@ -0,0 +1,294 @@
+package grpcapi_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/grpcapi"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"github.com/raphael-foliveira/go-table-tests/pkg/mocks"
+	usersv1 "github.com/raphael-foliveira/go-table-tests/pkg/proto/users/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testAPIKey = "test-api-key"
+
+func setUpTestClient(t *testing.T) (usersv1.UsersServiceClient, *mocks.MockUsersService) {
+	mockUsersService := mocks.NewMockUsersService(t)
+
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		grpcapi.RequestIDInterceptor(),
+		grpcapi.APIKeyInterceptor([]string{testAPIKey}),
+		grpcapi.ErrorInterceptor(logging.Discard()),
+	))
+	usersv1.RegisterUsersServiceServer(grpcServer, grpcapi.NewUsersServer(mockUsersService))
+
+	listener := bufconn.Listen(1024 * 1024)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return usersv1.NewUsersServiceClient(conn), mockUsersService
+}
+
+func authenticated(apiKey string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+apiKey)
+}
+
+func TestUsersServer_Login(t *testing.T) {
+	client, mockUsersService := setUpTestClient(t)
+
+	mockUsersService.EXPECT().
+		Login(mock.Anything, "valid@user.com", "unhashedPassword").
+		Return(&domain.LoginResponse{Email: "valid@user.com", Username: "validuser"}, nil)
+
+	response, err := client.Login(authenticated(testAPIKey), &usersv1.LoginRequest{
+		Email:    "valid@user.com",
+		Password: "unhashedPassword",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "valid@user.com", response.GetEmail())
+	assert.Equal(t, "validuser", response.GetUsername())
+}
+
+func TestUsersServer_Signup(t *testing.T) {
+	client, mockUsersService := setUpTestClient(t)
+
+	mockUsersService.EXPECT().
+		Signup(mock.Anything, &domain.SignupPayload{
+			Email:    "valid@user.com",
+			Username: "validuser",
+			Password: "unhashedPassword",
+		}).
+		Return(&domain.SignupResponse{ID: 1, Email: "valid@user.com", Username: "validuser"}, nil)
+
+	response, err := client.Signup(authenticated(testAPIKey), &usersv1.SignupRequest{
+		Email:    "valid@user.com",
+		Username: "validuser",
+		Password: "unhashedPassword",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), response.GetId())
+	assert.Equal(t, "valid@user.com", response.GetEmail())
+	assert.Equal(t, "validuser", response.GetUsername())
+}
+
+func TestUsersServer_Errors(t *testing.T) {
+	tests := []struct {
+		serviceErr      error
+		testName        string
+		expectedMessage string
+		expectedReason  string
+		expectedFields  []string
+		expectedCode    codes.Code
+	}{
+		{
+			testName:        "Invalid credentials",
+			serviceErr:      service.ErrInvalidCredentials,
+			expectedCode:    codes.Unauthenticated,
+			expectedMessage: "invalid credentials",
+			expectedReason:  "invalid_credentials",
+		},
+		{
+			testName:        "Email taken",
+			serviceErr:      service.ErrEmailAlreadyTaken,
+			expectedCode:    codes.AlreadyExists,
+			expectedMessage: "email already taken",
+			expectedReason:  "email_taken",
+			expectedFields:  []string{"email"},
+		},
+		{
+			testName:        "Single validation error",
+			serviceErr:      fmt.Errorf("%w: %w", service.ErrInvalidUserPayload, domain.ErrPasswordTooShort),
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: "password is too short",
+			expectedReason:  "password_too_short",
+			expectedFields:  []string{"password"},
+		},
+		{
+			testName:        "Password too long",
+			serviceErr:      fmt.Errorf("%w: %w", service.ErrInvalidUserPayload, domain.ErrPasswordTooLong),
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: "password is too long",
+			expectedReason:  "password_too_long",
+			expectedFields:  []string{"password"},
+		},
+		{
+			testName: "Several validation errors",
+			serviceErr: fmt.Errorf("%w: %w", service.ErrInvalidUserPayload, domain.ValidationErrors{
+				domain.ErrPasswordTooShort,
+				domain.ErrUsernameTooShort,
+			}),
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: "validation failed",
+			expectedReason:  "validation_failed",
+			expectedFields:  []string{"password", "username"},
+		},
+		{
+			testName:        "Unknown error",
+			serviceErr:      errors.New("connection refused"),
+			expectedCode:    codes.Internal,
+			expectedMessage: "internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			client, mockUsersService := setUpTestClient(t)
+
+			mockUsersService.EXPECT().
+				Signup(mock.Anything, mock.Anything).
+				Return(nil, tt.serviceErr)
+
+			_, err := client.Signup(authenticated(testAPIKey), &usersv1.SignupRequest{})
+
+			st := status.Convert(err)
+			assert.Equal(t, tt.expectedCode, st.Code())
+			assert.Equal(t, tt.expectedMessage, st.Message())
+
+			var reason string
+			var fields []string
+			for _, detail := range st.Details() {
+				switch detail := detail.(type) {
+				case *errdetails.ErrorInfo:
+					reason = detail.GetReason()
+					assert.Equal(t, grpcapi.ErrorDomain, detail.GetDomain())
+				case *errdetails.BadRequest:
+					for _, violation := range detail.GetFieldViolations() {
+						fields = append(fields, violation.GetField())
+					}
+				}
+			}
+			assert.Equal(t, tt.expectedReason, reason)
+			assert.Equal(t, tt.expectedFields, fields)
+		})
+	}
+}
+
+func TestUsersServer_Authentication(t *testing.T) {
+	tests := []struct {
+		ctx          context.Context
+		testName     string
+		expectedCode codes.Code
+	}{
+		{
+			testName:     "Missing API key",
+			ctx:          context.Background(),
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			testName:     "Wrong API key",
+			ctx:          authenticated("wrong-key"),
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			testName:     "Wrong scheme",
+			ctx:          metadata.AppendToOutgoingContext(context.Background(), "authorization", "Basic "+testAPIKey),
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			testName:     "Valid API key",
+			ctx:          authenticated(testAPIKey),
+			expectedCode: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			client, mockUsersService := setUpTestClient(t)
+
+			if tt.expectedCode == codes.OK {
+				mockUsersService.EXPECT().
+					Login(mock.Anything, mock.Anything, mock.Anything).
+					Return(&domain.LoginResponse{}, nil)
+			}
+
+			_, err := client.Login(tt.ctx, &usersv1.LoginRequest{})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestUsersServer_RequestID(t *testing.T) {
+	tests := []struct {
+		testName        string
+		requestID       string
+		expectGenerated bool
+	}{
+		{
+			testName:  "Propagates the caller's ID",
+			requestID: "caller-id-1",
+		},
+		{
+			testName:        "Generates a missing ID",
+			expectGenerated: true,
+		},
+		{
+			testName:        "Replaces an unsafe ID",
+			requestID:       "forged id=1",
+			expectGenerated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			client, mockUsersService := setUpTestClient(t)
+
+			var seen string
+			mockUsersService.EXPECT().
+				Signup(mock.Anything, mock.Anything).
+				RunAndReturn(func(ctx context.Context, _ *domain.SignupPayload) (*domain.SignupResponse, error) {
+					seen = logging.RequestIDFromContext(ctx)
+					return nil, errors.New("connection refused")
+				})
+
+			ctx := authenticated(testAPIKey)
+			if tt.requestID != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "x-request-id", tt.requestID)
+			}
+			var header metadata.MD
+			_, err := client.Signup(ctx, &usersv1.SignupRequest{}, grpc.Header(&header))
+
+			require.NotEmpty(t, seen)
+			if tt.expectGenerated {
+				assert.NotEqual(t, tt.requestID, seen)
+			} else {
+				assert.Equal(t, tt.requestID, seen)
+			}
+			assert.Equal(t, []string{seen}, header.Get("x-request-id"))
+
+			var requestID string
+			for _, detail := range status.Convert(err).Details() {
+				if info, ok := detail.(*errdetails.RequestInfo); ok {
+					requestID = info.GetRequestId()
+				}
+			}
+			assert.Equal(t, seen, requestID)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+)
+
+type discard struct{}
+
+// Discard returns a ports.Metrics that records nothing, for tests and tools
+// that do not export metrics.
+func Discard() ports.Metrics {
+	return discard{}
+}
+
+func (discard) RecordLogin(string)                   {}
+func (discard) RecordSignup(string)                  {}
+func (discard) ObserveHashing(string, time.Duration) {}
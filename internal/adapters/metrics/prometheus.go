@@ -0,0 +1,128 @@
+// Package metrics implements ports.Metrics with Prometheus, and instruments
+// the HTTP server and database pool alongside it.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	Path = "/metrics"
+	// DefaultAddress is where the metrics listener accepts connections,
+	// apart from the public API.
+	DefaultAddress = "127.0.0.1:9464"
+	namespace      = "users"
+
+	// unmatchedRoute labels requests that matched no route, so that
+	// arbitrary paths cannot inflate the number of series.
+	unmatchedRoute = "unmatched"
+)
+
+type Prometheus struct {
+	registry        *prometheus.Registry
+	logins          *prometheus.CounterVec
+	signups         *prometheus.CounterVec
+	hashingDuration *prometheus.HistogramVec
+	requestDuration *prometheus.HistogramVec
+}
+
+// NewPrometheus registers its metrics, along with the Go runtime and
+// process collectors, on a registry of its own.
+func NewPrometheus() *Prometheus {
+	p := &Prometheus{
+		registry: prometheus.NewRegistry(),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "logins_total",
+			Help:      "Login attempts by outcome.",
+		}, []string{"outcome"}),
+		signups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "signups_total",
+			Help:      "Signup attempts by outcome.",
+		}, []string{"outcome"}),
+		hashingDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "password_hashing_duration_seconds",
+			Help:      "Duration of password hashing operations.",
+			// bcrypt at the default cost takes tens of milliseconds and
+			// grows quickly under CPU contention.
+			Buckets: prometheus.ExponentialBuckets(0.005, 2, 12),
+		}, []string{"operation"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of HTTP requests by route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+	}
+
+	p.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		p.logins,
+		p.signups,
+		p.hashingDuration,
+		p.requestDuration,
+	)
+	return p
+}
+
+func (p *Prometheus) RecordLogin(outcome string) {
+	p.logins.WithLabelValues(outcome).Inc()
+}
+
+func (p *Prometheus) RecordSignup(outcome string) {
+	p.signups.WithLabelValues(outcome).Inc()
+}
+
+func (p *Prometheus) ObserveHashing(operation string, duration time.Duration) {
+	p.hashingDuration.WithLabelValues(operation).Observe(duration.Seconds())
+}
+
+// RegisterDB exposes the connection pool statistics of db, labelled with
+// name.
+func (p *Prometheus) RegisterDB(db *sql.DB, name string) {
+	p.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+func (p *Prometheus) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{Registry: p.registry})
+}
+
+func (p *Prometheus) SetupRoutes(app *echo.Echo) {
+	app.GET(Path, echo.WrapHandler(p.Handler()))
+}
+
+// Middleware observes every request under its route template. Like the
+// request logger, it hands errors to the error handler itself so that the
+// recorded status is the one sent.
+func (p *Prometheus) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			start := time.Now()
+			if err := next(ctx); err != nil {
+				ctx.Error(err)
+			}
+
+			route := ctx.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+			p.requestDuration.WithLabelValues(
+				ctx.Request().Method,
+				route,
+				strconv.Itoa(ctx.Response().Status),
+			).Observe(time.Since(start).Seconds())
+			return nil
+		}
+	}
+}
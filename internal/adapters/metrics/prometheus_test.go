@@ -0,0 +1,78 @@
+package metrics_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/metrics"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, app *echo.Echo) string {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	app.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, metrics.Path, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	body, err := io.ReadAll(recorder.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestPrometheus_Middleware(t *testing.T) {
+	prom := metrics.NewPrometheus()
+	app := echo.New()
+	app.Use(prom.Middleware())
+	prom.SetupRoutes(app)
+	app.GET("/api/users/:id", func(ctx echo.Context) error {
+		if ctx.Param("id") == "0" {
+			return echo.NewHTTPError(http.StatusNotFound)
+		}
+		return ctx.NoContent(http.StatusNoContent)
+	})
+	app.GET("/api/fail", func(ctx echo.Context) error {
+		return errors.New("connection refused")
+	})
+
+	for _, path := range []string{"/api/users/1", "/api/users/2", "/api/users/0", "/api/fail", "/does/not/exist"} {
+		app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	body := scrape(t, app)
+	assert.Contains(t, body, `users_http_request_duration_seconds_count{method="GET",route="/api/users/:id",status="204"} 2`)
+	assert.Contains(t, body, `users_http_request_duration_seconds_count{method="GET",route="/api/users/:id",status="404"} 1`)
+	assert.Contains(t, body, `users_http_request_duration_seconds_count{method="GET",route="/api/fail",status="500"} 1`)
+	assert.Contains(t, body, `users_http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`)
+	assert.NotContains(t, body, "/does/not/exist")
+}
+
+func TestPrometheus_Recorders(t *testing.T) {
+	prom := metrics.NewPrometheus()
+	app := echo.New()
+	prom.SetupRoutes(app)
+
+	db, err := database.NewSQLite(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	prom.RegisterDB(db.DB, "users")
+
+	prom.RecordLogin("success")
+	prom.RecordLogin("wrong_password")
+	prom.RecordLogin("wrong_password")
+	prom.RecordSignup("email_taken")
+	prom.ObserveHashing("hash", 50*time.Millisecond)
+
+	body := scrape(t, app)
+	assert.Contains(t, body, `users_logins_total{outcome="success"} 1`)
+	assert.Contains(t, body, `users_logins_total{outcome="wrong_password"} 2`)
+	assert.Contains(t, body, `users_signups_total{outcome="email_taken"} 1`)
+	assert.Contains(t, body, `users_password_hashing_duration_seconds_count{operation="hash"} 1`)
+	assert.Contains(t, body, `go_sql_max_open_connections{db_name="users"}`)
+}
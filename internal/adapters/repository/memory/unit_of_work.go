@@ -0,0 +1,45 @@
+package memoryRepository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+)
+
+// UnitOfWork serializes every Do call, which makes it trivially isolated,
+// and rolls the writes of users back when fn fails.
+type UnitOfWork struct {
+	users *Users
+	mu    sync.Mutex
+}
+
+func NewUnitOfWork(users *Users) *UnitOfWork {
+	return &UnitOfWork{
+		users: users,
+	}
+}
+
+type transaction struct {
+	users *Users
+}
+
+func (t *transaction) Users() ports.UsersRepository {
+	return t.users
+}
+
+func (u *UnitOfWork) Do(ctx context.Context, fn func(tx ports.Transaction) error) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	snapshot := u.users.snapshot()
+	err := fn(&transaction{users: u.users})
+	if err != nil {
+		u.users.restore(snapshot)
+	}
+	return err
+}
@@ -0,0 +1,176 @@
+package memoryRepository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
+)
+
+type Users struct {
+	users  map[uint]*domain.User
+	nextID uint
+	mu     sync.RWMutex
+}
+
+func NewUsers() *Users {
+	return &Users{
+		users:  map[uint]*domain.User{},
+		nextID: 1,
+	}
+}
+
+func (r *Users) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.findOne(func(user *domain.User) bool {
+		return user.Email.Value == email
+	}), nil
+}
+
+func (r *Users) FindByUsername(ctx context.Context, username string) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	skeleton := domain.UsernameSkeleton(username)
+	return r.findOne(func(user *domain.User) bool {
+		return domain.UsernameSkeleton(user.Username) == skeleton
+	}), nil
+}
+
+func (r *Users) FindByID(ctx context.Context, id uint) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil, nil
+	}
+	return copyUser(user), nil
+}
+
+func (r *Users) List(ctx context.Context, after uint, limit int) ([]*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]*domain.User, 0, limit)
+	for id, user := range r.users {
+		if id > after {
+			users = append(users, copyUser(user))
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users[:min(len(users), limit)], nil
+}
+
+func (r *Users) Create(ctx context.Context, user *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.findOne(func(u *domain.User) bool { return u.Email.Value == user.Email.Value }) != nil {
+		return service.ErrEmailAlreadyTaken
+	}
+	skeleton := domain.UsernameSkeleton(user.Username)
+	if r.findOne(func(u *domain.User) bool { return domain.UsernameSkeleton(u.Username) == skeleton }) != nil {
+		return service.ErrUsernameAlreadyTaken
+	}
+
+	user.ID = r.nextID
+	r.nextID++
+	r.users[user.ID] = copyUser(user)
+	return nil
+}
+
+func (r *Users) CreateBatch(ctx context.Context, users []*domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	emails := map[string]bool{}
+	skeletons := map[string]bool{}
+	for _, user := range users {
+		skeleton := domain.UsernameSkeleton(user.Username)
+		if emails[user.Email.Value] || r.findOne(func(u *domain.User) bool { return u.Email.Value == user.Email.Value }) != nil {
+			return service.ErrEmailAlreadyTaken
+		}
+		if skeletons[skeleton] || r.findOne(func(u *domain.User) bool { return domain.UsernameSkeleton(u.Username) == skeleton }) != nil {
+			return service.ErrUsernameAlreadyTaken
+		}
+		emails[user.Email.Value] = true
+		skeletons[skeleton] = true
+	}
+
+	for _, user := range users {
+		user.ID = r.nextID
+		r.nextID++
+		r.users[user.ID] = copyUser(user)
+	}
+	return nil
+}
+
+func (r *Users) Update(ctx context.Context, user *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.users[user.ID]
+	if !ok {
+		return nil
+	}
+	updated := copyUser(existing)
+	updated.Password = &domain.Password{Value: user.Password.Value, IsHashed: user.Password.IsHashed}
+	updated.Role = user.Role
+	updated.Disabled = user.Disabled
+	r.users[user.ID] = updated
+	return nil
+}
+
+func (r *Users) findOne(match func(user *domain.User) bool) *domain.User {
+	for _, user := range r.users {
+		if match(user) {
+			return copyUser(user)
+		}
+	}
+	return nil
+}
+
+func copyUser(user *domain.User) *domain.User {
+	return &domain.User{
+		ID:       user.ID,
+		Username: user.Username,
+		Email: &domain.Email{
+			Value: user.Email.Value,
+		},
+		Password: &domain.Password{
+			Value:    user.Password.Value,
+			IsHashed: user.Password.IsHashed,
+		},
+		Role:     user.Role,
+		Disabled: user.Disabled,
+	}
+}
+
+type usersSnapshot struct {
+	users  map[uint]*domain.User
+	nextID uint
+}
+
+func (r *Users) snapshot() usersSnapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make(map[uint]*domain.User, len(r.users))
+	for id, user := range r.users {
+		users[id] = copyUser(user)
+	}
+	return usersSnapshot{users: users, nextID: r.nextID}
+}
+
+func (r *Users) restore(snapshot usersSnapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.users = snapshot.users
+	r.nextID = snapshot.nextID
+}
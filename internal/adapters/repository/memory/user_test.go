@@ -0,0 +1,22 @@
+package memoryRepository_test
+
+import (
+	"testing"
+
+	memoryRepository "github.com/raphael-foliveira/go-table-tests/internal/adapters/repository/memory"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/repository/repositorytest"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+)
+
+func TestUsers_Contract(t *testing.T) {
+	repositorytest.RunUsersRepositoryTests(t, func(t *testing.T) ports.UsersRepository {
+		return memoryRepository.NewUsers()
+	})
+}
+
+func TestUnitOfWork_Contract(t *testing.T) {
+	repositorytest.RunUnitOfWorkTests(t, func(t *testing.T) (ports.UnitOfWork, ports.UsersRepository) {
+		users := memoryRepository.NewUsers()
+		return memoryRepository.NewUnitOfWork(users), users
+	})
+}
@@ -0,0 +1,80 @@
+package postgresRepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/repository/sqltrace"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+)
+
+const DefaultMaxAttempts = 3
+
+type UnitOfWork struct {
+	db          *sqlx.DB
+	tracer      ports.Tracer
+	maxAttempts int
+}
+
+func NewUnitOfWork(db *sqlx.DB, maxAttempts int, tracer ports.Tracer) *UnitOfWork {
+	if maxAttempts < 1 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	return &UnitOfWork{
+		db:          db,
+		tracer:      tracer,
+		maxAttempts: maxAttempts,
+	}
+}
+
+type transaction struct {
+	users *Users
+}
+
+func (t *transaction) Users() ports.UsersRepository {
+	return t.users
+}
+
+// Do runs fn in a serializable transaction, retrying the whole function when
+// Postgres aborts it with a serialization failure or a deadlock.
+func (u *UnitOfWork) Do(ctx context.Context, fn func(tx ports.Transaction) error) error {
+	var err error
+	for attempt := 1; attempt <= u.maxAttempts; attempt++ {
+		err = u.attempt(ctx, fn)
+		if !isRetryable(err) {
+			return err
+		}
+	}
+	return err
+}
+
+func (u *UnitOfWork) attempt(ctx context.Context, fn func(tx ports.Transaction) error) error {
+	tx, err := u.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return err
+	}
+
+	err = fn(&transaction{users: &Users{db: sqltrace.Wrap(tx, u.tracer, dbSystem)}})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+const (
+	serializationFailure pq.ErrorCode = "40001"
+	deadlockDetected     pq.ErrorCode = "40P01"
+)
+
+func isRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == serializationFailure || pqErr.Code == deadlockDetected
+}
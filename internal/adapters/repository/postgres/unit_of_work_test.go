@@ -0,0 +1,119 @@
+package postgresRepository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/lib/pq"
+	postgresRepository "github.com/raphael-foliveira/go-table-tests/internal/adapters/repository/postgres"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/tracing"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitOfWork_Retries(t *testing.T) {
+	serializationFailure := &pq.Error{Code: "40001"}
+
+	tests := []struct {
+		err              error
+		name             string
+		failures         int
+		maxAttempts      int
+		expectedAttempts int
+		expectErr        bool
+	}{
+		{
+			name:             "Serialization failure is retried",
+			err:              serializationFailure,
+			failures:         1,
+			maxAttempts:      3,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "Deadlock is retried",
+			err:              &pq.Error{Code: "40P01"},
+			failures:         1,
+			maxAttempts:      3,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "Wrapped serialization failure is retried",
+			err:              fmt.Errorf("create user: %w", serializationFailure),
+			failures:         1,
+			maxAttempts:      3,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "Unique violation is not retried",
+			err:              &pq.Error{Code: "23505"},
+			failures:         1,
+			maxAttempts:      3,
+			expectedAttempts: 1,
+			expectErr:        true,
+		},
+		{
+			name:             "Other errors are not retried",
+			err:              errors.New("boom"),
+			failures:         1,
+			maxAttempts:      3,
+			expectedAttempts: 1,
+			expectErr:        true,
+		},
+		{
+			name:             "Gives up after max attempts",
+			err:              serializationFailure,
+			failures:         10,
+			maxAttempts:      3,
+			expectedAttempts: 3,
+			expectErr:        true,
+		},
+		{
+			name:             "Single attempt",
+			err:              serializationFailure,
+			failures:         10,
+			maxAttempts:      1,
+			expectedAttempts: 1,
+			expectErr:        true,
+		},
+		{
+			name:             "Invalid max attempts falls back to the default",
+			err:              serializationFailure,
+			failures:         10,
+			maxAttempts:      0,
+			expectedAttempts: postgresRepository.DefaultMaxAttempts,
+			expectErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The retry loop only looks at the errors fn returns, so any
+			// database able to open transactions will do.
+			db, err := database.NewSQLite(filepath.Join(t.TempDir(), "retries.db"))
+			require.NoError(t, err)
+			t.Cleanup(func() { db.Close() })
+			unitOfWork := postgresRepository.NewUnitOfWork(db, tt.maxAttempts, tracing.Discard())
+
+			attempts := 0
+			err = unitOfWork.Do(context.Background(), func(ports.Transaction) error {
+				attempts++
+				if attempts <= tt.failures {
+					return tt.err
+				}
+				return nil
+			})
+
+			assert.Equal(t, tt.expectedAttempts, attempts)
+			if tt.expectErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
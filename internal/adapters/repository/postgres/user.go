@@ -2,60 +2,145 @@ package postgresRepository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/raphael-foliveira/go-table-tests/internal/adapters/dto"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/repository/sqltrace"
 	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
 )
 
+// dbSystem is the db.system attribute of the spans of each statement.
+const dbSystem = "postgresql"
+
 type Users struct {
-	db *sqlx.DB
+	db *sqltrace.DB
 }
 
-func NewUsers(db *sqlx.DB) *Users {
+func NewUsers(db *sqlx.DB, tracer ports.Tracer) *Users {
 	return &Users{
-		db: db,
+		db: sqltrace.Wrap(db, tracer, dbSystem),
 	}
 }
 
-func (r *Users) FindByEmail(email string) (*domain.User, error) {
-	var user dto.User
-	err := r.queryWithContext(&user, "SELECT * FROM users WHERE email = $1", email)
-	return user.ToDomainUser(), err
+func (r *Users) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.findOne(ctx, "SELECT * FROM users WHERE email = $1", email)
 }
 
-func (r *Users) FindByUsername(username string) (*domain.User, error) {
-	var user dto.User
-	err := r.queryWithContext(&user, "SELECT * FROM users WHERE username = $1", username)
-	return user.ToDomainUser(), err
+func (r *Users) FindByUsername(ctx context.Context, username string) (*domain.User, error) {
+	return r.findOne(ctx, "SELECT * FROM users WHERE username_skeleton = $1", domain.UsernameSkeleton(username))
+}
+
+func (r *Users) FindByID(ctx context.Context, id uint) (*domain.User, error) {
+	return r.findOne(ctx, "SELECT * FROM users WHERE id = $1", id)
+}
+
+func (r *Users) List(ctx context.Context, after uint, limit int) ([]*domain.User, error) {
+	var users []dto.User
+	err := r.db.SelectContext(ctx, &users, "SELECT * FROM users WHERE id > $1 ORDER BY id LIMIT $2", after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	domainUsers := make([]*domain.User, 0, len(users))
+	for _, user := range users {
+		domainUsers = append(domainUsers, user.ToDomainUser())
+	}
+	return domainUsers, nil
 }
 
-func (r *Users) Create(user *domain.User) error {
-	return r.queryWithContext(user,
-		"INSERT INTO users (username, email, password) VALUES ($1, $2, $3) RETURNING *",
+func (r *Users) Create(ctx context.Context, user *domain.User) error {
+	err := r.db.GetContext(ctx, &user.ID,
+		"INSERT INTO users (username, username_skeleton, email, password, role) VALUES ($1, $2, $3, $4, $5) RETURNING id",
 		user.Username,
-		user.Email,
-		user.Password,
+		domain.UsernameSkeleton(user.Username),
+		user.Email.Value,
+		user.Password.Value,
+		user.Role,
 	)
+	return mapConstraintError(err)
 }
 
-func (r *Users) queryWithContext(target interface{}, query string, args ...interface{}) error {
-	tx, err := r.db.BeginTxx(context.Background(), nil)
-	if err != nil {
-		return err
+// CreateBatch inserts every user with a single statement. IDs are matched
+// back by email, which is unique.
+func (r *Users) CreateBatch(ctx context.Context, users []*domain.User) error {
+	if len(users) == 0 {
+		return nil
 	}
 
-	stmt, err := tx.Preparex(query)
-	if err != nil {
-		return err
+	var query strings.Builder
+	query.WriteString("INSERT INTO users (username, username_skeleton, email, password, role) VALUES ")
+	args := make([]interface{}, 0, len(users)*5)
+	for i, user := range users {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		n := i * 5
+		fmt.Fprintf(&query, "($%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5)
+		args = append(args, user.Username, domain.UsernameSkeleton(user.Username), user.Email.Value, user.Password.Value, user.Role)
 	}
+	query.WriteString(" RETURNING id, email")
 
-	err = stmt.Get(target, args...)
+	var created []dto.User
+	err := r.db.SelectContext(ctx, &created, query.String(), args...)
 	if err != nil {
-		tx.Rollback()
-		return err
+		return mapConstraintError(err)
 	}
 
-	tx.Commit()
+	ids := make(map[string]uint, len(created))
+	for _, user := range created {
+		ids[user.Email] = user.ID
+	}
+	for _, user := range users {
+		user.ID = ids[user.Email.Value]
+	}
 	return nil
 }
+
+func (r *Users) Update(ctx context.Context, user *domain.User) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE users SET password = $1, role = $2, disabled = $3 WHERE id = $4",
+		user.Password.Value,
+		user.Role,
+		user.Disabled,
+		user.ID,
+	)
+	return err
+}
+
+func (r *Users) findOne(ctx context.Context, query string, args ...interface{}) (*domain.User, error) {
+	var user dto.User
+	err := r.db.GetContext(ctx, &user, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user.ToDomainUser(), nil
+}
+
+const uniqueViolation pq.ErrorCode = "23505"
+
+var constraintErrors = map[string]error{
+	"users_email_key":             service.ErrEmailAlreadyTaken,
+	"users_username_key":          service.ErrUsernameAlreadyTaken,
+	"users_username_skeleton_key": service.ErrUsernameAlreadyTaken,
+}
+
+func mapConstraintError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != uniqueViolation {
+		return err
+	}
+	if mapped, ok := constraintErrors[pqErr.Constraint]; ok {
+		return fmt.Errorf("%w: %w", mapped, err)
+	}
+	return err
+}
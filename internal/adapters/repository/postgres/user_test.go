@@ -0,0 +1,159 @@
+package postgresRepository_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	postgresRepository "github.com/raphael-foliveira/go-table-tests/internal/adapters/repository/postgres"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/repository/repositorytest"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/tracing"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const databaseURLVariable = "TEST_DATABASE_URL"
+
+func setUpDatabase(t *testing.T) *sqlx.DB {
+	databaseURL := os.Getenv(databaseURLVariable)
+	if databaseURL == "" {
+		t.Skipf("%s not set", databaseURLVariable)
+	}
+
+	db, err := database.New(databaseURL)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, database.Migrate(db))
+	return db
+}
+
+func truncateUsers(t *testing.T, db *sqlx.DB) {
+	_, err := db.Exec("TRUNCATE users RESTART IDENTITY CASCADE")
+	require.NoError(t, err)
+}
+
+func TestUsers_Contract(t *testing.T) {
+	db := setUpDatabase(t)
+
+	repositorytest.RunUsersRepositoryTests(t, func(t *testing.T) ports.UsersRepository {
+		truncateUsers(t, db)
+		return postgresRepository.NewUsers(db, tracing.Discard())
+	})
+}
+
+func TestUnitOfWork_Contract(t *testing.T) {
+	db := setUpDatabase(t)
+
+	repositorytest.RunUnitOfWorkTests(t, func(t *testing.T) (ports.UnitOfWork, ports.UsersRepository) {
+		truncateUsers(t, db)
+		return postgresRepository.NewUnitOfWork(db, postgresRepository.DefaultMaxAttempts, tracing.Discard()), postgresRepository.NewUsers(db, tracing.Discard())
+	})
+}
+
+func TestCheckMigrations(t *testing.T) {
+	db := setUpDatabase(t)
+
+	require.NoError(t, database.CheckMigrations(context.Background(), db))
+}
+
+func TestMigrate_BackfillsUsernameSkeletons(t *testing.T) {
+	db := setUpDatabase(t)
+	truncateUsers(t, db)
+	t.Cleanup(func() { truncateUsers(t, db) })
+
+	// Rows as left by the SQL backfill this migration replaces: skeletons
+	// that domain.UsernameSkeleton never produces, and look-alike
+	// usernames that predate the uniqueness check.
+	_, err := db.Exec("ALTER TABLE users DROP CONSTRAINT users_username_skeleton_key")
+	require.NoError(t, err)
+	for _, username := range []string{"Alice", "alice", "ＡＬＩＣＥ", "bob"} {
+		_, err := db.Exec("INSERT INTO users (username, username_skeleton, email, password) VALUES ($1, 'stale', $2, 'hash')",
+			username, username+"@user.com")
+		require.NoError(t, err)
+	}
+	_, err = db.Exec("DELETE FROM schema_migrations WHERE version = '0005_backfill_username_skeletons'")
+	require.NoError(t, err)
+
+	require.NoError(t, database.Migrate(db))
+
+	var users []struct {
+		Username string `db:"username"`
+		Skeleton string `db:"username_skeleton"`
+	}
+	require.NoError(t, db.Select(&users, "SELECT username, username_skeleton FROM users ORDER BY id"))
+	require.Len(t, users, 4)
+	assert.Equal(t, "Alice", users[0].Username)
+	assert.Equal(t, "alice_2", users[1].Username)
+	assert.Equal(t, "ＡＬＩＣＥ_3", users[2].Username)
+	assert.Equal(t, "bob", users[3].Username)
+	for _, user := range users {
+		assert.Equal(t, domain.UsernameSkeleton(user.Username), user.Skeleton)
+	}
+
+	var renames int
+	require.NoError(t, db.Get(&renames, "SELECT count(*) FROM username_renames"))
+	assert.Equal(t, 2, renames)
+}
+
+func TestMigrate_NormalizesEmails(t *testing.T) {
+	db := setUpDatabase(t)
+	truncateUsers(t, db)
+	t.Cleanup(func() { truncateUsers(t, db) })
+
+	// Emails as stored before Signup normalized them. Only the second bob
+	// is in normalized form, so it is the account Login finds today.
+	for i, email := range []string{
+		"Alice@Example.COM",
+		"BOB@example.com",
+		"bob@example.com",
+		"Carol@example.com",
+		"CAROL@example.com",
+		"not an email",
+	} {
+		username := fmt.Sprintf("user%d", i)
+		_, err := db.Exec("INSERT INTO users (username, username_skeleton, email, password) VALUES ($1, $2, $3, 'hash')",
+			username, domain.UsernameSkeleton(username), email)
+		require.NoError(t, err)
+	}
+	_, err := db.Exec("DELETE FROM schema_migrations WHERE version = '0006_normalize_emails'")
+	require.NoError(t, err)
+
+	require.NoError(t, database.Migrate(db))
+
+	var emails []string
+	require.NoError(t, db.Select(&emails, "SELECT email FROM users ORDER BY id"))
+	assert.Equal(t, []string{
+		"alice@example.com",
+		"BOB@example.com",
+		"bob@example.com",
+		"carol@example.com",
+		"CAROL@example.com",
+		"not an email",
+	}, emails)
+
+	var conflicts []struct {
+		Email  string `db:"email"`
+		Reason string `db:"reason"`
+	}
+	require.NoError(t, db.Select(&conflicts, "SELECT email, reason FROM email_conflicts ORDER BY user_id"))
+	require.Len(t, conflicts, 3)
+	assert.Equal(t, "BOB@example.com", conflicts[0].Email)
+	assert.Equal(t, "duplicate", conflicts[0].Reason)
+	assert.Equal(t, "CAROL@example.com", conflicts[1].Email)
+	assert.Equal(t, "duplicate", conflicts[1].Reason)
+	assert.Equal(t, "not an email", conflicts[2].Email)
+	assert.Equal(t, "invalid", conflicts[2].Reason)
+
+	// The mixed-case user is found by the key Login looks up.
+	normalized, err := (&domain.Email{Value: "Alice@Example.COM"}).Normalized()
+	require.NoError(t, err)
+	user, err := postgresRepository.NewUsers(db, tracing.Discard()).FindByEmail(context.Background(), normalized)
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	assert.Equal(t, "user0", user.Username)
+}
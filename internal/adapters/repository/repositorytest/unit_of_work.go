@@ -0,0 +1,119 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/metrics"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/security"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/tracing"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// UnitOfWorkFactory returns an empty unit of work together with a repository
+// reading from the same store outside of any transaction.
+type UnitOfWorkFactory func(t *testing.T) (ports.UnitOfWork, ports.UsersRepository)
+
+func RunUnitOfWorkTests(t *testing.T, newUnitOfWork UnitOfWorkFactory) {
+	t.Run("Commits on success", func(t *testing.T) {
+		unitOfWork, users := newUnitOfWork(t)
+		testCommitsOnSuccess(t, unitOfWork, users)
+	})
+	t.Run("Rolls back on error", func(t *testing.T) {
+		unitOfWork, users := newUnitOfWork(t)
+		testRollsBackOnError(t, unitOfWork, users)
+	})
+	t.Run("Concurrent signups", func(t *testing.T) {
+		unitOfWork, users := newUnitOfWork(t)
+		testConcurrentSignups(t, unitOfWork, users)
+	})
+}
+
+func testCommitsOnSuccess(t *testing.T, unitOfWork ports.UnitOfWork, users ports.UsersRepository) {
+	ctx := context.Background()
+
+	err := unitOfWork.Do(ctx, func(tx ports.Transaction) error {
+		if err := tx.Users().Create(ctx, NewUser("first", "first@user.com")); err != nil {
+			return err
+		}
+		return tx.Users().Create(ctx, NewUser("second", "second@user.com"))
+	})
+	require.NoError(t, err)
+
+	for _, email := range []string{"first@user.com", "second@user.com"} {
+		found, err := users.FindByEmail(ctx, email)
+		require.NoError(t, err)
+		assert.NotNil(t, found, email)
+	}
+}
+
+func testRollsBackOnError(t *testing.T, unitOfWork ports.UnitOfWork, users ports.UsersRepository) {
+	ctx := context.Background()
+	errAbort := errors.New("abort")
+
+	err := unitOfWork.Do(ctx, func(tx ports.Transaction) error {
+		if err := tx.Users().Create(ctx, NewUser("first", "first@user.com")); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+
+	found, err := users.FindByEmail(ctx, "first@user.com")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
+
+// testConcurrentSignups races signups for the same email through the
+// service, whose existence check and insert only stay consistent if the unit
+// of work isolates them.
+func testConcurrentSignups(t *testing.T, unitOfWork ports.UnitOfWork, users ports.UsersRepository) {
+	ctx := context.Background()
+	const attempts = 10
+	usersService := service.NewUsersService(users, security.NewHashingService(bcrypt.MinCost), unitOfWork,
+		logging.Discard(), metrics.Discard(), tracing.Discard())
+
+	var wg sync.WaitGroup
+	errs := make(chan error, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := usersService.Signup(ctx, &domain.SignupPayload{
+				Username: fmt.Sprintf("user%d", i),
+				Email:    "same@user.com",
+				Password: "unhashedPassword",
+			})
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded, conflicts := 0, 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case errors.Is(err, service.ErrEmailAlreadyTaken):
+			conflicts++
+		default:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, attempts-1, conflicts)
+
+	found, err := users.FindByEmail(ctx, "same@user.com")
+	require.NoError(t, err)
+	assert.NotNil(t, found)
+}
@@ -0,0 +1,390 @@
+// Package repositorytest holds the behavioral contract every
+// ports.UsersRepository adapter must satisfy. Adapter packages run it from
+// their own tests so that a new backend cannot silently diverge.
+package repositorytest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// UsersRepositoryFactory returns an empty repository. It is called once per
+// subtest, so implementations must not share state between calls.
+type UsersRepositoryFactory func(t *testing.T) ports.UsersRepository
+
+func RunUsersRepositoryTests(t *testing.T, newRepository UsersRepositoryFactory) {
+	t.Run("Create assigns IDs", func(t *testing.T) {
+		testCreateAssignsIDs(t, newRepository(t))
+	})
+	t.Run("Find returns created user", func(t *testing.T) {
+		testFindReturnsCreatedUser(t, newRepository(t))
+	})
+	t.Run("Find not found", func(t *testing.T) {
+		testFindNotFound(t, newRepository(t))
+	})
+	t.Run("Unique violations", func(t *testing.T) {
+		testUniqueViolations(t, newRepository(t))
+	})
+	t.Run("Case sensitivity", func(t *testing.T) {
+		testCaseSensitivity(t, newRepository(t))
+	})
+	t.Run("Confusable usernames", func(t *testing.T) {
+		testConfusableUsernames(t, newRepository(t))
+	})
+	t.Run("Concurrent creates", func(t *testing.T) {
+		testConcurrentCreates(t, newRepository(t))
+	})
+	t.Run("Roles", func(t *testing.T) {
+		testRoles(t, newRepository(t))
+	})
+	t.Run("List", func(t *testing.T) {
+		testList(t, newRepository(t))
+	})
+	t.Run("Update", func(t *testing.T) {
+		testUpdate(t, newRepository(t))
+	})
+	t.Run("CreateBatch", func(t *testing.T) {
+		testCreateBatch(t, newRepository(t))
+	})
+	t.Run("CreateBatch is atomic", func(t *testing.T) {
+		testCreateBatchAtomic(t, newRepository(t))
+	})
+}
+
+func NewUser(username, email string) *domain.User {
+	return &domain.User{
+		Username: username,
+		Email: &domain.Email{
+			Value: email,
+		},
+		Password: &domain.Password{
+			Value:    "hashed_password",
+			IsHashed: true,
+		},
+		Role: domain.RoleUser,
+	}
+}
+
+func testCreateAssignsIDs(t *testing.T, repository ports.UsersRepository) {
+	ctx := context.Background()
+	first := NewUser("first", "first@user.com")
+	second := NewUser("second", "second@user.com")
+
+	require.NoError(t, repository.Create(ctx, first))
+	require.NoError(t, repository.Create(ctx, second))
+
+	assert.NotZero(t, first.ID)
+	assert.NotZero(t, second.ID)
+	assert.NotEqual(t, first.ID, second.ID)
+}
+
+func testFindReturnsCreatedUser(t *testing.T, repository ports.UsersRepository) {
+	ctx := context.Background()
+	created := NewUser("testuser", "test@user.com")
+	require.NoError(t, repository.Create(ctx, created))
+
+	tests := []struct {
+		find func() (*domain.User, error)
+		name string
+	}{
+		{
+			name: "FindByEmail",
+			find: func() (*domain.User, error) { return repository.FindByEmail(ctx, "test@user.com") },
+		},
+		{
+			name: "FindByUsername",
+			find: func() (*domain.User, error) { return repository.FindByUsername(ctx, "testuser") },
+		},
+		{
+			name: "FindByID",
+			find: func() (*domain.User, error) { return repository.FindByID(ctx, created.ID) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := tt.find()
+			require.NoError(t, err)
+			require.NotNil(t, found)
+
+			assert.Equal(t, created.ID, found.ID)
+			assert.Equal(t, created.Username, found.Username)
+			assert.Equal(t, created.Email.Value, found.Email.Value)
+			assert.Equal(t, created.Password.Value, found.Password.Value)
+			assert.True(t, found.Password.IsHashed)
+		})
+	}
+}
+
+func testFindNotFound(t *testing.T, repository ports.UsersRepository) {
+	ctx := context.Background()
+	require.NoError(t, repository.Create(ctx, NewUser("testuser", "test@user.com")))
+
+	tests := []struct {
+		find func() (*domain.User, error)
+		name string
+	}{
+		{
+			name: "FindByEmail",
+			find: func() (*domain.User, error) { return repository.FindByEmail(ctx, "missing@user.com") },
+		},
+		{
+			name: "FindByUsername",
+			find: func() (*domain.User, error) { return repository.FindByUsername(ctx, "missing") },
+		},
+		{
+			name: "FindByID",
+			find: func() (*domain.User, error) { return repository.FindByID(ctx, 999) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := tt.find()
+			assert.NoError(t, err)
+			assert.Nil(t, found)
+		})
+	}
+}
+
+func testUniqueViolations(t *testing.T, repository ports.UsersRepository) {
+	ctx := context.Background()
+	require.NoError(t, repository.Create(ctx, NewUser("testuser", "test@user.com")))
+
+	tests := []struct {
+		expectedError error
+		user          *domain.User
+		name          string
+	}{
+		{
+			name:          "Duplicate email",
+			user:          NewUser("otheruser", "test@user.com"),
+			expectedError: service.ErrEmailAlreadyTaken,
+		},
+		{
+			name:          "Duplicate username",
+			user:          NewUser("testuser", "other@user.com"),
+			expectedError: service.ErrUsernameAlreadyTaken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := repository.Create(ctx, tt.user)
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+// Repositories compare emails byte for byte: normalizing them is the
+// domain's job. Usernames are matched by domain.UsernameSkeleton so that
+// case variants and look-alikes resolve to the same account.
+func testCaseSensitivity(t *testing.T, repository ports.UsersRepository) {
+	ctx := context.Background()
+	require.NoError(t, repository.Create(ctx, NewUser("testuser", "test@user.com")))
+
+	found, err := repository.FindByEmail(ctx, "TEST@user.com")
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+
+	found, err = repository.FindByUsername(ctx, "TestUser")
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+
+	assert.NoError(t, repository.Create(ctx, NewUser("otheruser", "TEST@user.com")))
+	assert.ErrorIs(t, repository.Create(ctx, NewUser("TestUser", "third@user.com")), service.ErrUsernameAlreadyTaken)
+}
+
+func testConfusableUsernames(t *testing.T, repository ports.UsersRepository) {
+	ctx := context.Background()
+	created := NewUser("paypal", "test@user.com")
+	require.NoError(t, repository.Create(ctx, created))
+
+	lookalikes := []string{
+		"pаypаl", // Cyrillic a
+		"paypaI", // capital i
+		"paypa1",
+		"ｐａｙｐａｌ", // fullwidth
+	}
+
+	for _, username := range lookalikes {
+		t.Run(username, func(t *testing.T) {
+			found, err := repository.FindByUsername(ctx, username)
+			require.NoError(t, err)
+			require.NotNil(t, found)
+			assert.Equal(t, created.ID, found.ID)
+
+			err = repository.Create(ctx, NewUser(username, username+"@user.com"))
+			assert.ErrorIs(t, err, service.ErrUsernameAlreadyTaken)
+		})
+	}
+}
+
+func testConcurrentCreates(t *testing.T, repository ports.UsersRepository) {
+	ctx := context.Background()
+	const attempts = 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- repository.Create(ctx, NewUser(fmt.Sprintf("user%d", i), "same@user.com"))
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, service.ErrEmailAlreadyTaken)
+	}
+	assert.Equal(t, 1, succeeded)
+
+	found, err := repository.FindByEmail(ctx, "same@user.com")
+	require.NoError(t, err)
+	assert.NotNil(t, found)
+}
+
+func testRoles(t *testing.T, repository ports.UsersRepository) {
+	ctx := context.Background()
+	admin := NewUser("admin_user", "admin@user.com")
+	admin.Role = domain.RoleAdmin
+	require.NoError(t, repository.Create(ctx, admin))
+	require.NoError(t, repository.Create(ctx, NewUser("regular", "regular@user.com")))
+
+	found, err := repository.FindByEmail(ctx, "admin@user.com")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleAdmin, found.Role)
+
+	found, err = repository.FindByEmail(ctx, "regular@user.com")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleUser, found.Role)
+}
+
+func testList(t *testing.T, repository ports.UsersRepository) {
+	ctx := context.Background()
+	var created []*domain.User
+	for i := 0; i < 5; i++ {
+		user := NewUser(fmt.Sprintf("user%d", i), fmt.Sprintf("user%d@user.com", i))
+		require.NoError(t, repository.Create(ctx, user))
+		created = append(created, user)
+	}
+
+	tests := []struct {
+		name        string
+		expectedIDs []uint
+		after       uint
+		limit       int
+	}{
+		{
+			name:        "First page",
+			limit:       2,
+			expectedIDs: []uint{created[0].ID, created[1].ID},
+		},
+		{
+			name:        "After cursor",
+			after:       created[1].ID,
+			limit:       2,
+			expectedIDs: []uint{created[2].ID, created[3].ID},
+		},
+		{
+			name:        "Last page",
+			after:       created[3].ID,
+			limit:       2,
+			expectedIDs: []uint{created[4].ID},
+		},
+		{
+			name:        "Past the end",
+			after:       created[4].ID,
+			limit:       2,
+			expectedIDs: []uint{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, err := repository.List(ctx, tt.after, tt.limit)
+			require.NoError(t, err)
+
+			ids := []uint{}
+			for _, user := range users {
+				ids = append(ids, user.ID)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func testUpdate(t *testing.T, repository ports.UsersRepository) {
+	ctx := context.Background()
+	created := NewUser("testuser", "test@user.com")
+	other := NewUser("otheruser", "other@user.com")
+	require.NoError(t, repository.Create(ctx, created))
+	require.NoError(t, repository.Create(ctx, other))
+
+	created.Password = &domain.Password{Value: "new_hashed_password", IsHashed: true}
+	created.Role = domain.RoleAdmin
+	created.Disabled = true
+	require.NoError(t, repository.Update(ctx, created))
+
+	found, err := repository.FindByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "new_hashed_password", found.Password.Value)
+	assert.Equal(t, domain.RoleAdmin, found.Role)
+	assert.True(t, found.Disabled)
+
+	untouched, err := repository.FindByID(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "hashed_password", untouched.Password.Value)
+	assert.Equal(t, domain.RoleUser, untouched.Role)
+	assert.False(t, untouched.Disabled)
+}
+
+func testCreateBatch(t *testing.T, repository ports.UsersRepository) {
+	ctx := context.Background()
+	users := []*domain.User{
+		NewUser("first", "first@user.com"),
+		NewUser("second", "second@user.com"),
+		NewUser("third", "third@user.com"),
+	}
+
+	require.NoError(t, repository.CreateBatch(ctx, users))
+
+	for _, user := range users {
+		require.NotZero(t, user.ID)
+		found, err := repository.FindByID(ctx, user.ID)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, user.Email.Value, found.Email.Value)
+		assert.Equal(t, user.Username, found.Username)
+	}
+}
+
+func testCreateBatchAtomic(t *testing.T, repository ports.UsersRepository) {
+	ctx := context.Background()
+	require.NoError(t, repository.Create(ctx, NewUser("existing", "existing@user.com")))
+
+	err := repository.CreateBatch(ctx, []*domain.User{
+		NewUser("fresh", "fresh@user.com"),
+		NewUser("other", "existing@user.com"),
+	})
+	assert.ErrorIs(t, err, service.ErrEmailAlreadyTaken)
+
+	found, err := repository.FindByEmail(ctx, "fresh@user.com")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
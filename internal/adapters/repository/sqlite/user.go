@@ -0,0 +1,158 @@
+package sqliteRepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/dto"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+const schema = `CREATE TABLE IF NOT EXISTS users (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	username TEXT NOT NULL UNIQUE,
+	username_skeleton TEXT NOT NULL UNIQUE,
+	email TEXT NOT NULL UNIQUE,
+	password TEXT NOT NULL,
+	role TEXT NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'admin')),
+	disabled BOOLEAN NOT NULL DEFAULT FALSE
+)`
+
+type Users struct {
+	db sqlx.ExtContext
+}
+
+func NewUsers(db *sqlx.DB) *Users {
+	return &Users{
+		db: db,
+	}
+}
+
+func CreateSchema(db *sqlx.DB) error {
+	_, err := db.Exec(schema)
+	return err
+}
+
+func (r *Users) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.findOne(ctx, "SELECT * FROM users WHERE email = ?", email)
+}
+
+func (r *Users) FindByUsername(ctx context.Context, username string) (*domain.User, error) {
+	return r.findOne(ctx, "SELECT * FROM users WHERE username_skeleton = ?", domain.UsernameSkeleton(username))
+}
+
+func (r *Users) FindByID(ctx context.Context, id uint) (*domain.User, error) {
+	return r.findOne(ctx, "SELECT * FROM users WHERE id = ?", id)
+}
+
+func (r *Users) List(ctx context.Context, after uint, limit int) ([]*domain.User, error) {
+	var users []dto.User
+	err := sqlx.SelectContext(ctx, r.db, &users, "SELECT * FROM users WHERE id > ? ORDER BY id LIMIT ?", after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	domainUsers := make([]*domain.User, 0, len(users))
+	for _, user := range users {
+		domainUsers = append(domainUsers, user.ToDomainUser())
+	}
+	return domainUsers, nil
+}
+
+func (r *Users) Create(ctx context.Context, user *domain.User) error {
+	err := sqlx.GetContext(ctx, r.db, &user.ID,
+		"INSERT INTO users (username, username_skeleton, email, password, role) VALUES (?, ?, ?, ?, ?) RETURNING id",
+		user.Username,
+		domain.UsernameSkeleton(user.Username),
+		user.Email.Value,
+		user.Password.Value,
+		user.Role,
+	)
+	return mapConstraintError(err)
+}
+
+// CreateBatch inserts every user with a single statement. IDs are matched
+// back by email, which is unique.
+func (r *Users) CreateBatch(ctx context.Context, users []*domain.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	var query strings.Builder
+	query.WriteString("INSERT INTO users (username, username_skeleton, email, password, role) VALUES ")
+	args := make([]interface{}, 0, len(users)*5)
+	for i, user := range users {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString("(?, ?, ?, ?, ?)")
+		args = append(args, user.Username, domain.UsernameSkeleton(user.Username), user.Email.Value, user.Password.Value, user.Role)
+	}
+	query.WriteString(" RETURNING id, email")
+
+	var created []dto.User
+	err := sqlx.SelectContext(ctx, r.db, &created, query.String(), args...)
+	if err != nil {
+		return mapConstraintError(err)
+	}
+
+	ids := make(map[string]uint, len(created))
+	for _, user := range created {
+		ids[user.Email] = user.ID
+	}
+	for _, user := range users {
+		user.ID = ids[user.Email.Value]
+	}
+	return nil
+}
+
+func (r *Users) Update(ctx context.Context, user *domain.User) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE users SET password = ?, role = ?, disabled = ? WHERE id = ?",
+		user.Password.Value,
+		user.Role,
+		user.Disabled,
+		user.ID,
+	)
+	return err
+}
+
+func (r *Users) findOne(ctx context.Context, query string, args ...interface{}) (*domain.User, error) {
+	var user dto.User
+	err := sqlx.GetContext(ctx, r.db, &user, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user.ToDomainUser(), nil
+}
+
+// SQLite does not report constraint names, only the offending columns, e.g.
+// "UNIQUE constraint failed: users.email". "users.username" also matches the
+// username_skeleton column.
+var constraintErrors = map[string]error{
+	"users.email":    service.ErrEmailAlreadyTaken,
+	"users.username": service.ErrUsernameAlreadyTaken,
+}
+
+func mapConstraintError(err error) error {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) || sqliteErr.Code() != sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+		return err
+	}
+	for column, mapped := range constraintErrors {
+		if strings.Contains(sqliteErr.Error(), column) {
+			return fmt.Errorf("%w: %w", mapped, err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,24 @@
+package sqliteRepository_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/repository/repositorytest"
+	sqliteRepository "github.com/raphael-foliveira/go-table-tests/internal/adapters/repository/sqlite"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/database"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsers_Contract(t *testing.T) {
+	repositorytest.RunUsersRepositoryTests(t, func(t *testing.T) ports.UsersRepository {
+		dsn := filepath.Join(t.TempDir(), "users.db") + "?_pragma=busy_timeout(5000)"
+		db, err := database.NewSQLite(dsn)
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		require.NoError(t, sqliteRepository.CreateSchema(db))
+		return sqliteRepository.NewUsers(db)
+	})
+}
@@ -0,0 +1,103 @@
+// Package sqltrace traces every statement run through a sqlx.ExtContext.
+package sqltrace
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+)
+
+// DB runs statements on a sqlx.ExtContext with a span around each one. The
+// span of a query lasts until its rows are closed, so that it covers reading
+// the results and reports the errors met while iterating over them.
+type DB struct {
+	ext    sqlx.ExtContext
+	tracer ports.Tracer
+	system string
+}
+
+// Wrap returns db with a span around each statement, named after its
+// operation, such as SELECT. system is the db.system attribute, e.g.
+// "postgresql". Arguments are not recorded since they include password
+// hashes.
+func Wrap(db sqlx.ExtContext, tracer ports.Tracer, system string) *DB {
+	return &DB{
+		ext:    db,
+		tracer: tracer,
+		system: system,
+	}
+}
+
+func (d *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := d.start(ctx, query)
+	result, err := d.ext.ExecContext(ctx, query, args...)
+	span.End(err)
+	return result, err
+}
+
+// QueryxContext ends the span when the returned rows are closed.
+func (d *DB) QueryxContext(ctx context.Context, query string, args ...interface{}) (*Rows, error) {
+	ctx, span := d.start(ctx, query)
+	rows, err := d.ext.QueryxContext(ctx, query, args...)
+	if err != nil {
+		span.End(err)
+		return nil, err
+	}
+	return &Rows{Rows: rows, span: span}, nil
+}
+
+// SelectContext is sqlx.SelectContext, traced until every row is scanned.
+func (d *DB) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, span := d.start(ctx, query)
+	err := sqlx.SelectContext(ctx, d.ext, dest, query, args...)
+	span.End(err)
+	return err
+}
+
+// GetContext is sqlx.GetContext, traced until the row is scanned. Finding no
+// row is not recorded as a failure.
+func (d *DB) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, span := d.start(ctx, query)
+	err := sqlx.GetContext(ctx, d.ext, dest, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		span.End(nil)
+	} else {
+		span.End(err)
+	}
+	return err
+}
+
+func (d *DB) start(ctx context.Context, query string) (context.Context, ports.Span) {
+	operation := "SQL"
+	if fields := strings.Fields(query); len(fields) > 0 {
+		operation = strings.ToUpper(fields[0])
+	}
+	return d.tracer.Start(ctx, operation,
+		ports.Attribute{Key: "db.system", Value: d.system},
+		ports.Attribute{Key: "db.operation", Value: operation},
+		ports.Attribute{Key: "db.statement", Value: query},
+	)
+}
+
+// Rows are the results of a traced query. Close must be called for the span
+// to end.
+type Rows struct {
+	*sqlx.Rows
+	span   ports.Span
+	closed bool
+}
+
+// Close closes the rows and ends the span with the error that ended the
+// iteration, if any.
+func (r *Rows) Close() error {
+	err := r.Rows.Close()
+	if !r.closed {
+		r.closed = true
+		r.span.End(errors.Join(r.Rows.Err(), err))
+	}
+	return err
+}
@@ -1,22 +1,66 @@
 package security
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
 
-type HashingService struct{}
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
 
-func NewHashingService() *HashingService {
-	return &HashingService{}
+type HashingService struct {
+	cost int
+}
+
+// NewHashingService hashes with the given bcrypt cost. Compare reads the cost
+// from each hash, so raising it does not invalidate existing passwords.
+func NewHashingService(cost int) *HashingService {
+	return &HashingService{cost: cost}
 }
 
 func (s *HashingService) Hash(str string) (string, error) {
-	b, err := bcrypt.GenerateFromPassword([]byte(str), bcrypt.DefaultCost)
+	b, err := bcrypt.GenerateFromPassword([]byte(str), s.cost)
 	if err != nil {
 		return "", err
 	}
 	return string(b), nil
 }
 
+// Compare accepts bcrypt hashes, which Hash produces, and argon2id hashes in
+// PHC string format, which imported users may carry over from another system.
 func (s *HashingService) Compare(givenPassword, hashedPassword string) bool {
+	if strings.HasPrefix(hashedPassword, "$argon2id$") {
+		return compareArgon2id(givenPassword, hashedPassword)
+	}
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(givenPassword))
-	return err != nil
+	return err == nil
+}
+
+func compareArgon2id(givenPassword, hashedPassword string) bool {
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 || parts[2] != fmt.Sprintf("v=%d", argon2.Version) {
+		return false
+	}
+
+	// argon2.IDKey panics on zero iterations or parallelism, so the
+	// parameters get the same checks as on import.
+	params, err := domain.ParseArgon2idParams(parts[3])
+	if err != nil {
+		return false
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return false
+	}
+
+	given := argon2.IDKey([]byte(givenPassword), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(given, key) == 1
 }
@@ -0,0 +1,96 @@
+package security_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHashingService_Compare(t *testing.T) {
+	hasher := security.NewHashingService(bcrypt.MinCost)
+	hashed, err := hasher.Hash("valid_password")
+	require.NoError(t, err)
+
+	salt := []byte("0123456789abcdef")
+	key := argon2.IDKey([]byte("valid_password"), salt, 1, 64*1024, 2, 32)
+	argon2Hashed := fmt.Sprintf(
+		"$argon2id$v=19$m=65536,t=1,p=2$%s$%s",
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+
+	tests := []struct {
+		name     string
+		given    string
+		hashed   string
+		expected bool
+	}{
+		{
+			name:     "Matching password",
+			given:    "valid_password",
+			hashed:   hashed,
+			expected: true,
+		},
+		{
+			name:     "Wrong password",
+			given:    "wrong_password",
+			hashed:   hashed,
+			expected: false,
+		},
+		{
+			name:     "Matching argon2id password",
+			given:    "valid_password",
+			hashed:   argon2Hashed,
+			expected: true,
+		},
+		{
+			name:     "Wrong argon2id password",
+			given:    "wrong_password",
+			hashed:   argon2Hashed,
+			expected: false,
+		},
+		{
+			name:     "Malformed argon2id hash",
+			given:    "valid_password",
+			hashed:   "$argon2id$v=19$m=65536$salt$key",
+			expected: false,
+		},
+		{
+			name:     "argon2id hash with zero iterations",
+			given:    "valid_password",
+			hashed:   strings.Replace(argon2Hashed, "t=1", "t=0", 1),
+			expected: false,
+		},
+		{
+			name:     "argon2id hash with zero parallelism",
+			given:    "valid_password",
+			hashed:   strings.Replace(argon2Hashed, "p=2", "p=0", 1),
+			expected: false,
+		},
+		{
+			name:     "argon2id hash with memory over the cap",
+			given:    "valid_password",
+			hashed:   strings.Replace(argon2Hashed, "m=65536", "m=4294967295", 1),
+			expected: false,
+		},
+		{
+			name:     "Unusable password",
+			given:    "!",
+			hashed:   "!",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, hasher.Compare(tt.given, tt.hashed))
+		})
+	}
+}
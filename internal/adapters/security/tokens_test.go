@@ -0,0 +1,90 @@
+package security_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/security"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var signingKey = []byte(strings.Repeat("k", security.MinSigningKeyLength))
+
+func TestNewTokens_ShortKey(t *testing.T) {
+	_, err := security.NewTokens([]byte("short"), time.Minute)
+	assert.ErrorIs(t, err, security.ErrSigningKeyTooShort)
+}
+
+func TestTokens_Verify(t *testing.T) {
+	tokens, err := security.NewTokens(signingKey, time.Minute)
+	require.NoError(t, err)
+	principal := &domain.Principal{Role: domain.RoleAdmin, UserID: 42}
+	issued, err := tokens.Issue(principal)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), issued.ExpiresAt, 2*time.Second)
+
+	otherKey, err := security.NewTokens([]byte(strings.Repeat("o", security.MinSigningKeyLength)), time.Minute)
+	require.NoError(t, err)
+	expiring, err := security.NewTokens(signingKey, -time.Second)
+	require.NoError(t, err)
+	expired, err := expiring.Issue(principal)
+	require.NoError(t, err)
+
+	payload, signature, _ := strings.Cut(strings.TrimPrefix(issued.Value, "v1."), ".")
+
+	tests := []struct {
+		expectedErr       error
+		expectedPrincipal *domain.Principal
+		tokens            *security.Tokens
+		name              string
+		token             string
+	}{
+		{
+			name:              "Valid token",
+			tokens:            tokens,
+			token:             issued.Value,
+			expectedPrincipal: principal,
+		},
+		{
+			name:        "Expired token",
+			tokens:      tokens,
+			token:       expired.Value,
+			expectedErr: security.ErrTokenExpired,
+		},
+		{
+			name:        "Signed with another key",
+			tokens:      otherKey,
+			token:       issued.Value,
+			expectedErr: security.ErrInvalidToken,
+		},
+		{
+			name:        "Tampered payload",
+			tokens:      tokens,
+			token:       "v1." + payload + "x." + signature,
+			expectedErr: security.ErrInvalidToken,
+		},
+		{
+			name:        "Unknown version",
+			tokens:      tokens,
+			token:       "v2." + payload + "." + signature,
+			expectedErr: security.ErrInvalidToken,
+		},
+		{
+			name:        "Malformed token",
+			tokens:      tokens,
+			token:       "not-a-token",
+			expectedErr: security.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verified, err := tt.tokens.Verify(tt.token)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedPrincipal, verified)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Package tracing implements ports.Tracer with OpenTelemetry, and traces
+// incoming HTTP requests, continuing the W3C trace context of the caller.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName names the tracer of every span started by this
+// service.
+const InstrumentationName = "github.com/raphael-foliveira/go-table-tests"
+
+type OTel struct {
+	tracer trace.Tracer
+}
+
+func NewOTel(provider trace.TracerProvider) *OTel {
+	return &OTel{
+		tracer: provider.Tracer(InstrumentationName),
+	}
+}
+
+func (t *OTel) Start(ctx context.Context, name string, attributes ...ports.Attribute) (context.Context, ports.Span) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(toKeyValues(attributes)...))
+	return ctx, &otelSpan{span: span}
+}
+
+type otelSpan struct {
+	span trace.Span
+}
+
+func (s *otelSpan) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
+
+func toKeyValues(attributes []ports.Attribute) []attribute.KeyValue {
+	keyValues := make([]attribute.KeyValue, 0, len(attributes))
+	for _, attr := range attributes {
+		switch value := attr.Value.(type) {
+		case string:
+			keyValues = append(keyValues, attribute.String(attr.Key, value))
+		case int:
+			keyValues = append(keyValues, attribute.Int(attr.Key, value))
+		case int64:
+			keyValues = append(keyValues, attribute.Int64(attr.Key, value))
+		case uint:
+			keyValues = append(keyValues, attribute.Int64(attr.Key, int64(value)))
+		case bool:
+			keyValues = append(keyValues, attribute.Bool(attr.Key, value))
+		default:
+			keyValues = append(keyValues, attribute.String(attr.Key, fmt.Sprint(value)))
+		}
+	}
+	return keyValues
+}
+
+type discard struct{}
+
+type discardSpan struct{}
+
+// Discard returns a ports.Tracer that records nothing, for tests and tools
+// that do not export traces.
+func Discard() ports.Tracer {
+	return discard{}
+}
+
+func (discard) Start(ctx context.Context, _ string, _ ...ports.Attribute) (context.Context, ports.Span) {
+	return ctx, discardSpan{}
+}
+
+func (discardSpan) End(error) {}
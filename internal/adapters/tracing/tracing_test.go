@@ -0,0 +1,205 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/metrics"
+	memoryRepository "github.com/raphael-foliveira/go-table-tests/internal/adapters/repository/memory"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/repository/sqltrace"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/tracing"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/database"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/server"
+	"github.com/raphael-foliveira/go-table-tests/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func setUpRecorder(t *testing.T) (*tracetest.SpanRecorder, *sdktrace.TracerProvider) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	return recorder, provider
+}
+
+func spansByName(spans []sdktrace.ReadOnlySpan) map[string]sdktrace.ReadOnlySpan {
+	byName := make(map[string]sdktrace.ReadOnlySpan, len(spans))
+	for _, span := range spans {
+		byName[span.Name()] = span
+	}
+	return byName
+}
+
+func TestTracing_LoginRequest(t *testing.T) {
+	recorder, provider := setUpRecorder(t)
+	tracer := tracing.NewOTel(provider)
+
+	hasherMock := mocks.NewMockHasher(t)
+	hasherMock.EXPECT().Compare("wrong_password", "hashed").Return(false)
+
+	users := memoryRepository.NewUsers()
+	require.NoError(t, users.Create(context.Background(), &domain.User{
+		Username: "testuser",
+		Email:    &domain.Email{Value: "test@user.com"},
+		Password: &domain.Password{Value: "hashed", IsHashed: true},
+		Role:     domain.RoleUser,
+	}))
+	usersService := service.NewUsersService(users, hasherMock, memoryRepository.NewUnitOfWork(users), logging.Discard(), metrics.Discard(), tracer)
+
+	app := server.CreateApp(logging.Discard(), server.DefaultSecurityOptions())
+	app.Use(tracing.Middleware(provider, propagation.TraceContext{}))
+	api.NewUsersHandler(usersService, api.NewSessions(mocks.NewMockTokenService(t), api.DefaultSessionOptions())).SetupRoutes(app.Group("/api"))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/users/login", strings.NewReader(`{"email": "test@user.com", "password": "wrong_password"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("traceparent", traceparent)
+	app.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	byName := spansByName(spans)
+
+	handler := byName["POST /api/users/login"]
+	require.NotNil(t, handler)
+	assert.Equal(t, trace.SpanKindServer, handler.SpanKind())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", handler.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", handler.Parent().SpanID().String())
+	assert.True(t, handler.Parent().IsRemote())
+	assert.Contains(t, handler.Attributes(), attribute.Int("http.response.status_code", http.StatusUnauthorized))
+	assert.Equal(t, codes.Unset, handler.Status().Code)
+
+	login := byName["Users.Login"]
+	require.NotNil(t, login)
+	assert.Equal(t, handler.SpanContext().SpanID(), login.Parent().SpanID())
+	assert.Equal(t, codes.Error, login.Status().Code)
+
+	compare := byName["Hasher.Compare"]
+	require.NotNil(t, compare)
+	assert.Equal(t, login.SpanContext().SpanID(), compare.Parent().SpanID())
+}
+
+func TestTracing_ServerErrorStatus(t *testing.T) {
+	recorder, provider := setUpRecorder(t)
+
+	app := echo.New()
+	app.Use(tracing.Middleware(provider, propagation.TraceContext{}))
+	app.GET("/fail", func(ctx echo.Context) error {
+		return echo.NewHTTPError(http.StatusServiceUnavailable)
+	})
+
+	app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+	app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "GET /fail", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.False(t, spans[0].Parent().IsValid())
+	assert.Equal(t, "GET unmatched", spans[1].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
+
+func TestSQLTrace(t *testing.T) {
+	recorder, provider := setUpRecorder(t)
+	tracer := tracing.NewOTel(provider)
+
+	db, err := database.NewSQLite(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	traced := sqltrace.Wrap(db, tracer, "sqlite")
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, err = traced.ExecContext(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, password TEXT)")
+	require.NoError(t, err)
+	_, err = traced.ExecContext(ctx, "INSERT INTO users (password) VALUES ($1)", "secret_hash")
+	require.NoError(t, err)
+	var id int
+	require.NoError(t, traced.GetContext(ctx, &id, "SELECT id FROM users"))
+	err = traced.GetContext(ctx, &id, "SELECT id FROM missing")
+	require.Error(t, err)
+	parent.End(nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 5)
+	var names []string
+	for _, span := range spans[:4] {
+		names = append(names, span.Name())
+		assert.Equal(t, spans[4].SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Contains(t, span.Attributes(), attribute.String("db.system", "sqlite"))
+		for _, attr := range span.Attributes() {
+			assert.NotContains(t, attr.Value.Emit(), "secret_hash")
+		}
+	}
+	assert.Equal(t, []string{"CREATE", "INSERT", "SELECT", "SELECT"}, names)
+	assert.Contains(t, spans[2].Attributes(), attribute.String("db.statement", "SELECT id FROM users"))
+	assert.Equal(t, codes.Error, spans[3].Status().Code)
+}
+
+func TestSQLTrace_Rows(t *testing.T) {
+	recorder, provider := setUpRecorder(t)
+	db, err := database.NewSQLite(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	traced := sqltrace.Wrap(db, tracing.NewOTel(provider), "sqlite")
+	ctx := context.Background()
+
+	_, err = traced.ExecContext(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY)")
+	require.NoError(t, err)
+	_, err = traced.ExecContext(ctx, "INSERT INTO users (id) VALUES (1), (2)")
+	require.NoError(t, err)
+	require.Len(t, recorder.Ended(), 2)
+
+	rows, err := traced.QueryxContext(ctx, "SELECT id FROM users")
+	require.NoError(t, err)
+	count := 0
+	for rows.Next() {
+		count++
+		assert.Len(t, recorder.Ended(), 2, "span ended while reading rows")
+	}
+	require.NoError(t, rows.Close())
+	require.NoError(t, rows.Close())
+	assert.Equal(t, 2, count)
+
+	var id int
+	err = traced.GetContext(ctx, &id, "SELECT id FROM users WHERE id = 3")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 4)
+	assert.Equal(t, "SELECT", spans[2].Name())
+	assert.Equal(t, codes.Unset, spans[2].Status().Code)
+	assert.Equal(t, codes.Unset, spans[3].Status().Code)
+}
+
+func TestNewProvider(t *testing.T) {
+	_, err := tracing.NewProvider(context.Background(), "zipkin", nil)
+	assert.ErrorIs(t, err, tracing.ErrUnknownExporter)
+
+	var buf bytes.Buffer
+	provider, err := tracing.NewProvider(context.Background(), tracing.ExporterStdout, &buf)
+	require.NoError(t, err)
+	_, span := tracing.NewOTel(provider).Start(context.Background(), "Users.Login")
+	span.End(nil)
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	assert.Contains(t, buf.String(), `"Name":"Users.Login"`)
+	assert.Contains(t, buf.String(), `"Value":"users"`)
+}
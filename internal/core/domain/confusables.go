@@ -0,0 +1,70 @@
+package domain
+
+// confusablePrototypes maps characters that render like a Latin letter or
+// digit to a shared prototype. It is a curated subset of the Unicode
+// confusables data (UTS #39) covering the Cyrillic, Greek and Latin
+// look-alikes seen in impersonation attempts. Keys are already case-folded
+// and decomposed, because UsernameSkeleton folds and decomposes first.
+//
+// The digits one and zero, lowercase L and the vertical bar share the
+// prototype "i" so that "paypaI", "paypa1" and "paypal" collide after case
+// folding.
+var confusablePrototypes = map[rune]string{
+	// Latin and ASCII
+	'0': "o",
+	'1': "i",
+	'l': "i",
+	'|': "i",
+	'ı': "i",
+	'ɩ': "i",
+	'ɑ': "a",
+	'ɡ': "g",
+	'ʋ': "u",
+	'ꞵ': "b",
+	'm': "rn",
+	'w': "vv",
+
+	// Cyrillic
+	'а': "a",
+	'в': "b",
+	'г': "r",
+	'е': "e",
+	'з': "3",
+	'і': "i",
+	'ӏ': "i",
+	'ј': "j",
+	'к': "k",
+	'м': "rn",
+	'н': "h",
+	'о': "o",
+	'п': "n",
+	'р': "p",
+	'с': "c",
+	'т': "t",
+	'у': "y",
+	'х': "x",
+	'ѕ': "s",
+	'ԁ': "d",
+	'ԛ': "q",
+	'ԝ': "vv",
+	'ь': "b",
+	'һ': "h",
+	'ү': "y",
+
+	// Greek
+	'α': "a",
+	'β': "b",
+	'ε': "e",
+	'ζ': "z",
+	'η': "h",
+	'ι': "i",
+	'κ': "k",
+	'μ': "rn",
+	'ν': "v",
+	'ο': "o",
+	'ρ': "p",
+	'τ': "t",
+	'υ': "u",
+	'χ': "x",
+	'ω': "vv",
+}
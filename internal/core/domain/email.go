@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"net/mail"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+	"golang.org/x/text/unicode/norm"
+)
+
+const EmailMaxLength = 254
+
+type Email struct {
+	Value string
+}
+
+// Validate checks an email about to be registered. Unlike Normalized, it
+// rejects disposable domains, so that blocking a domain stops new accounts
+// without locking out the existing ones.
+func (e *Email) Validate() error {
+	_, domain, err := parseEmail(e.Value)
+	if err != nil {
+		return err
+	}
+	if currentEmailPolicy().disposableDomains[domain] {
+		return ErrEmailDisposable
+	}
+	return nil
+}
+
+// Normalized parses Value as an RFC 5322 addr-spec and returns the form used
+// for storage and lookups: surrounding whitespace removed, the local part
+// NFC-normalized and lower-cased, and the domain converted to lower-case
+// punycode. With EmailPolicy.CanonicalizeAliases set, provider aliases such
+// as plus-addressing and Gmail dots are folded into the base mailbox.
+func (e *Email) Normalized() (string, error) {
+	localPart, domain, err := parseEmail(e.Value)
+	if err != nil {
+		return "", err
+	}
+
+	if currentEmailPolicy().CanonicalizeAliases {
+		localPart, domain = canonicalizeAlias(localPart, domain)
+	}
+
+	return formatEmail(localPart, domain), nil
+}
+
+func parseEmail(value string) (localPart, domain string, err error) {
+	value = strings.TrimSpace(value)
+	if value == "" || utf8.RuneCountInString(value) > EmailMaxLength || strings.ContainsAny(value, "<>") {
+		return "", "", ErrEmailInvalid
+	}
+
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Name != "" {
+		return "", "", ErrEmailInvalid
+	}
+
+	at := strings.LastIndex(address.Address, "@")
+	localPart = strings.ToLower(norm.NFC.String(address.Address[:at]))
+	domain, err = idna.Lookup.ToASCII(address.Address[at+1:])
+	if err != nil || !strings.Contains(domain, ".") {
+		return "", "", ErrEmailInvalid
+	}
+
+	return localPart, strings.ToLower(domain), nil
+}
+
+// formatEmail re-quotes local parts that need it, e.g. "john doe"@x.com.
+func formatEmail(localPart, domain string) string {
+	formatted := (&mail.Address{Address: localPart + "@" + domain}).String()
+	return strings.TrimSuffix(strings.TrimPrefix(formatted, "<"), ">")
+}
+
+type aliasRule struct {
+	canonicalDomain string
+	ignoreDots      bool
+}
+
+// Only providers known to deliver subaddresses to the base mailbox are
+// canonicalized, so the stored address stays deliverable.
+var aliasRules = map[string]aliasRule{
+	"gmail.com":      {canonicalDomain: "gmail.com", ignoreDots: true},
+	"googlemail.com": {canonicalDomain: "gmail.com", ignoreDots: true},
+	"outlook.com":    {canonicalDomain: "outlook.com"},
+	"hotmail.com":    {canonicalDomain: "hotmail.com"},
+	"live.com":       {canonicalDomain: "live.com"},
+	"icloud.com":     {canonicalDomain: "icloud.com"},
+	"fastmail.com":   {canonicalDomain: "fastmail.com"},
+	"proton.me":      {canonicalDomain: "proton.me"},
+	"protonmail.com": {canonicalDomain: "protonmail.com"},
+}
+
+func canonicalizeAlias(localPart, domain string) (string, string) {
+	rule, ok := aliasRules[domain]
+	if !ok {
+		return localPart, domain
+	}
+	if plus := strings.Index(localPart, "+"); plus > 0 {
+		localPart = localPart[:plus]
+	}
+	if rule.ignoreDots {
+		localPart = strings.ReplaceAll(localPart, ".", "")
+	}
+	return localPart, rule.canonicalDomain
+}
+
+type EmailPolicy struct {
+	// DisposableDomains are rejected by Email.Validate. Subdomains are not
+	// matched implicitly; list them explicitly if needed.
+	DisposableDomains []string
+	disposableDomains map[string]bool
+	// CanonicalizeAliases changes the form Normalized stores, so it must not
+	// change while users are registered without migrating their emails.
+	CanonicalizeAliases bool
+}
+
+var (
+	emailPolicy   = EmailPolicy{}
+	emailPolicyMu sync.RWMutex
+)
+
+func SetEmailPolicy(policy EmailPolicy) {
+	policy.disposableDomains = make(map[string]bool, len(policy.DisposableDomains))
+	for _, domain := range policy.DisposableDomains {
+		if ascii, err := idna.Lookup.ToASCII(strings.TrimSpace(domain)); err == nil {
+			policy.disposableDomains[strings.ToLower(ascii)] = true
+		}
+	}
+
+	emailPolicyMu.Lock()
+	defer emailPolicyMu.Unlock()
+
+	emailPolicy = policy
+}
+
+func currentEmailPolicy() EmailPolicy {
+	emailPolicyMu.RLock()
+	defer emailPolicyMu.RUnlock()
+
+	return emailPolicy
+}
+
+var (
+	ErrEmailInvalid    = NewValidationError("email", "invalid", "email is not valid")
+	ErrEmailDisposable = NewValidationError("email", "disposable", "disposable email addresses are not allowed")
+)
@@ -0,0 +1,118 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmail_Normalized(t *testing.T) {
+	tests := []struct {
+		expectedError error
+		policy        domain.EmailPolicy
+		name          string
+		email         string
+		expected      string
+	}{
+		{
+			name:     "Case and whitespace",
+			email:    "  John.Doe@Email.COM ",
+			expected: "john.doe@email.com",
+		},
+		{
+			name:     "Internationalized domain",
+			email:    "user@Bücher.example",
+			expected: "user@xn--bcher-kva.example",
+		},
+		{
+			name:     "Unicode local part",
+			email:    "JOSÉ@example.com",
+			expected: "josé@example.com",
+		},
+		{
+			name:     "Quoted local part",
+			email:    `"John Doe"@example.com`,
+			expected: `"john doe"@example.com`,
+		},
+		{
+			name:     "Aliases kept by default",
+			email:    "john.doe+news@gmail.com",
+			expected: "john.doe+news@gmail.com",
+		},
+		{
+			name:     "Gmail aliases canonicalized",
+			policy:   domain.EmailPolicy{CanonicalizeAliases: true},
+			email:    "John.Doe+news@googlemail.com",
+			expected: "johndoe@gmail.com",
+		},
+		{
+			name:     "Plus addressing canonicalized",
+			policy:   domain.EmailPolicy{CanonicalizeAliases: true},
+			email:    "john.doe+news@outlook.com",
+			expected: "john.doe@outlook.com",
+		},
+		{
+			name:     "Unknown provider not canonicalized",
+			policy:   domain.EmailPolicy{CanonicalizeAliases: true},
+			email:    "john.doe+news@example.com",
+			expected: "john.doe+news@example.com",
+		},
+		{
+			name:     "Disposable domain still normalized",
+			policy:   domain.EmailPolicy{DisposableDomains: []string{"Mailinator.com"}},
+			email:    "John@mailinator.com",
+			expected: "john@mailinator.com",
+		},
+		{
+			name:          "Invalid",
+			email:         "not an email",
+			expectedError: domain.ErrEmailInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domain.SetEmailPolicy(tt.policy)
+			t.Cleanup(func() { domain.SetEmailPolicy(domain.EmailPolicy{}) })
+
+			normalized, err := (&domain.Email{Value: tt.email}).Normalized()
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expected, normalized)
+		})
+	}
+}
+
+func TestEmail_Validate(t *testing.T) {
+	tests := []struct {
+		expectedError error
+		policy        domain.EmailPolicy
+		name          string
+		email         string
+	}{
+		{
+			name:  "Valid",
+			email: "john@example.com",
+		},
+		{
+			name:          "Disposable domain",
+			policy:        domain.EmailPolicy{DisposableDomains: []string{"Mailinator.com"}},
+			email:         "john@MAILINATOR.com",
+			expectedError: domain.ErrEmailDisposable,
+		},
+		{
+			name:          "Invalid",
+			email:         "not an email",
+			expectedError: domain.ErrEmailInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domain.SetEmailPolicy(tt.policy)
+			t.Cleanup(func() { domain.SetEmailPolicy(domain.EmailPolicy{}) })
+
+			assert.ErrorIs(t, (&domain.Email{Value: tt.email}).Validate(), tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// ImportRecord is one row of a bulk import. PasswordHash is either a bcrypt
+// or an argon2id (PHC string) hash; when it is empty the user is created
+// without a usable password and invited to set one.
+type ImportRecord struct {
+	Email        string
+	Username     string
+	PasswordHash string
+	Role         Role
+	Line         int
+}
+
+var (
+	bcryptHash        = regexp.MustCompile(`^\$2[aby]\$\d{2}\$[./A-Za-z0-9]{53}$`)
+	argon2idHash      = regexp.MustCompile(`^\$argon2id\$v=19\$([^$]+)\$[A-Za-z0-9+/]+\$[A-Za-z0-9+/]+$`)
+	argon2idParamsFmt = regexp.MustCompile(`^m=(\d+),t=(\d+),p=(\d+)$`)
+)
+
+func ValidatePasswordHash(hash string) error {
+	if bcryptHash.MatchString(hash) {
+		return nil
+	}
+	if match := argon2idHash.FindStringSubmatch(hash); match != nil {
+		_, err := ParseArgon2idParams(match[1])
+		return err
+	}
+	return ErrPasswordHashUnsupported
+}
+
+// MaxArgon2idMemory caps the memory parameter of an argon2id hash, in KiB.
+// Every login against the hash allocates that much, so one imported row
+// must not be able to exhaust the server.
+const MaxArgon2idMemory = 1 << 20
+
+// Argon2idParams are the cost parameters of an argon2id hash.
+type Argon2idParams struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// ParseArgon2idParams parses the "m=...,t=...,p=..." segment of an argon2id
+// PHC string. It returns ErrPasswordHashUnsupported for parameters argon2
+// rejects or that exceed MaxArgon2idMemory.
+func ParseArgon2idParams(segment string) (*Argon2idParams, error) {
+	match := argon2idParamsFmt.FindStringSubmatch(segment)
+	if match == nil {
+		return nil, ErrPasswordHashUnsupported
+	}
+
+	memory, err := strconv.ParseUint(match[1], 10, 32)
+	if err != nil || memory > MaxArgon2idMemory {
+		return nil, ErrPasswordHashUnsupported
+	}
+	iterations, err := strconv.ParseUint(match[2], 10, 32)
+	if err != nil || iterations < 1 {
+		return nil, ErrPasswordHashUnsupported
+	}
+	parallelism, err := strconv.ParseUint(match[3], 10, 8)
+	if err != nil || parallelism < 1 {
+		return nil, ErrPasswordHashUnsupported
+	}
+
+	return &Argon2idParams{
+		Memory:      uint32(memory),
+		Iterations:  uint32(iterations),
+		Parallelism: uint8(parallelism),
+	}, nil
+}
+
+// UnusablePassword matches no input, so the user has to reset it before
+// logging in.
+const UnusablePassword = "!"
+
+// ToDomainUser builds the user to create. A missing role defaults to
+// RoleUser.
+func (r *ImportRecord) ToDomainUser() *User {
+	role := r.Role
+	if role == "" {
+		role = RoleUser
+	}
+
+	password := r.PasswordHash
+	if password == "" {
+		password = UnusablePassword
+	}
+
+	return &User{
+		Username: NormalizeUsername(r.Username),
+		Email: &Email{
+			Value: r.Email,
+		},
+		Password: &Password{
+			Value:    password,
+			IsHashed: true,
+		},
+		Role: role,
+	}
+}
+
+// Validate applies the same rules as User.Validate, except that the password
+// must be a supported hash instead of a plain text password.
+func (r *ImportRecord) Validate() error {
+	user := r.ToDomainUser()
+
+	var errs ValidationErrors
+	errs = errs.Append(user.Email.Validate())
+	if r.PasswordHash != "" {
+		errs = errs.Append(ValidatePasswordHash(r.PasswordHash))
+	}
+	errs = errs.Append(ValidateUsername(user.Username))
+	errs = errs.Append(user.Role.Validate())
+	for _, rule := range registeredUserRules() {
+		errs = errs.Append(rule(user))
+	}
+	return errs.OrNil()
+}
+
+// ImportOptions tunes Import. Without ContinueOnError the import stops at
+// the first failing row; batches committed before it are kept.
+type ImportOptions struct {
+	BatchSize       int
+	DryRun          bool
+	ContinueOnError bool
+}
+
+type ImportRowError struct {
+	Err  error
+	Line int
+}
+
+func (e *ImportRowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ImportRowError) Unwrap() error {
+	return e.Err
+}
+
+// ImportReport counts rows by outcome. In a dry run Created and Invited
+// count the rows that would have been.
+type ImportReport struct {
+	Errors  []*ImportRowError
+	Created int
+	Invited int
+	Failed  int
+}
+
+var (
+	ErrPasswordHashUnsupported = NewValidationError("password_hash", "unsupported", "password hash must be bcrypt or argon2id")
+	ErrPasswordHashRequired    = NewValidationError("password_hash", "required", "password hash is required when invites are disabled")
+)
@@ -0,0 +1,72 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportRecord_ValidatePasswordHash(t *testing.T) {
+	const salt, key = "c2FsdHNhbHRzYWx0c2FsdA", "a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U"
+
+	tests := []struct {
+		expectedError error
+		name          string
+		hash          string
+	}{
+		{
+			name: "bcrypt",
+			hash: "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy",
+		},
+		{
+			name: "argon2id",
+			hash: "$argon2id$v=19$m=65536,t=3,p=4$" + salt + "$" + key,
+		},
+		{
+			name: "argon2id at the memory cap",
+			hash: "$argon2id$v=19$m=1048576,t=1,p=1$" + salt + "$" + key,
+		},
+		{
+			name:          "argon2id with zero iterations",
+			hash:          "$argon2id$v=19$m=65536,t=0,p=4$" + salt + "$" + key,
+			expectedError: domain.ErrPasswordHashUnsupported,
+		},
+		{
+			name:          "argon2id with zero parallelism",
+			hash:          "$argon2id$v=19$m=65536,t=3,p=0$" + salt + "$" + key,
+			expectedError: domain.ErrPasswordHashUnsupported,
+		},
+		{
+			name:          "argon2id with parallelism out of range",
+			hash:          "$argon2id$v=19$m=65536,t=3,p=256$" + salt + "$" + key,
+			expectedError: domain.ErrPasswordHashUnsupported,
+		},
+		{
+			name:          "argon2id over the memory cap",
+			hash:          "$argon2id$v=19$m=4194304,t=3,p=4$" + salt + "$" + key,
+			expectedError: domain.ErrPasswordHashUnsupported,
+		},
+		{
+			name:          "argon2id with malformed parameters",
+			hash:          "$argon2id$v=19$m=65536,t=3$" + salt + "$" + key,
+			expectedError: domain.ErrPasswordHashUnsupported,
+		},
+		{
+			name:          "argon2i",
+			hash:          "$argon2i$v=19$m=65536,t=3,p=4$" + salt + "$" + key,
+			expectedError: domain.ErrPasswordHashUnsupported,
+		},
+		{
+			name:          "Plain text",
+			hash:          "valid_password",
+			expectedError: domain.ErrPasswordHashUnsupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, domain.ValidatePasswordHash(tt.hash), tt.expectedError)
+		})
+	}
+}
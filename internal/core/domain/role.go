@@ -0,0 +1,49 @@
+package domain
+
+import "context"
+
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
+func (r Role) Valid() bool {
+	return r == RoleUser || r == RoleAdmin
+}
+
+func (r Role) Validate() error {
+	if !r.Valid() {
+		return ErrRoleInvalid
+	}
+	return nil
+}
+
+var ErrRoleInvalid = NewValidationError("role", "invalid", "role is not valid")
+
+// Principal is the authenticated caller of a request. Transports attach it to
+// the context once credentials have been verified; services read it back to
+// authorize the operation.
+type Principal struct {
+	Role Role
+	// Subject identifies callers that are not users, such as internal
+	// services authenticated by their client certificate.
+	Subject string
+	UserID  uint
+}
+
+func (p *Principal) IsAdmin() bool {
+	return p.Role == RoleAdmin
+}
+
+type principalKey struct{}
+
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
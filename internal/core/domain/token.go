@@ -0,0 +1,9 @@
+package domain
+
+import "time"
+
+// AccessToken authenticates a principal until ExpiresAt.
+type AccessToken struct {
+	ExpiresAt time.Time
+	Value     string
+}
@@ -1,15 +1,14 @@
 package domain
 
-import (
-	"errors"
-	"strings"
-)
-
 type Password struct {
 	Value    string
 	IsHashed bool
 }
 
+// MaxPasswordBytes is the longest password bcrypt accepts. The limit is in
+// bytes, not characters, so multibyte passwords reach it sooner.
+const MaxPasswordBytes = 72
+
 func (p *Password) Validate() error {
 	if p.IsHashed {
 		return ErrPasswordAlreadyHashed
@@ -17,16 +16,8 @@ func (p *Password) Validate() error {
 	if len(p.Value) < 6 {
 		return ErrPasswordTooShort
 	}
-	return nil
-}
-
-type Email struct {
-	Value string
-}
-
-func (e *Email) Validate() error {
-	if !strings.Contains(e.Value, "@") {
-		return ErrEmailInvalid
+	if len(p.Value) > MaxPasswordBytes {
+		return ErrPasswordTooLong
 	}
 	return nil
 }
@@ -35,22 +26,29 @@ type User struct {
 	Password *Password
 	Email    *Email
 	Username string
+	Role     Role
 	ID       uint
+	Disabled bool
 }
 
+// Validate runs every built-in rule plus the rules registered with
+// RegisterUserRule and reports all violations at once as ValidationErrors.
 func (u *User) Validate() error {
-	if err := u.Email.Validate(); err != nil {
-		return err
+	var errs ValidationErrors
+	errs = errs.Append(u.Email.Validate())
+	errs = errs.Append(u.Password.Validate())
+	errs = errs.Append(ValidateUsername(u.Username))
+	for _, rule := range registeredUserRules() {
+		errs = errs.Append(rule(u))
 	}
-	if err := u.Password.Validate(); err != nil {
-		return err
-	}
-	return nil
+	return errs.OrNil()
 }
 
 type LoginResponse struct {
 	Username string
 	Email    string
+	Role     Role
+	UserID   uint
 }
 
 type SignupPayload struct {
@@ -61,24 +59,47 @@ type SignupPayload struct {
 
 func (p *SignupPayload) ToDomainUser() *User {
 	return &User{
-		Username: p.Username,
+		Username: NormalizeUsername(p.Username),
 		Email: &Email{
 			Value: p.Email,
 		},
 		Password: &Password{
 			Value: p.Password,
 		},
+		Role: RoleUser,
 	}
 }
 
 type SignupResponse struct {
 	Username string
 	Email    string
+	Role     Role
 	ID       uint
 }
 
+// UserProfile is the public view of a user, without credentials.
+type UserProfile struct {
+	Username string
+	Email    string
+	Role     Role
+	ID       uint
+	Disabled bool
+}
+
+// ListUsersQuery selects a page of users ordered by ID. After is the ID of
+// the last user of the previous page, zero for the first page.
+type ListUsersQuery struct {
+	After uint
+	First int
+}
+
+type UsersPage struct {
+	Users       []*UserProfile
+	HasNextPage bool
+}
+
 var (
-	ErrPasswordTooShort      = errors.New("password is too short")
-	ErrPasswordAlreadyHashed = errors.New("password already hashed")
-	ErrEmailInvalid          = errors.New("email is not valid")
+	ErrPasswordTooShort      = NewValidationError("password", "too_short", "password is too short")
+	ErrPasswordTooLong       = NewValidationError("password", "too_long", "password is too long")
+	ErrPasswordAlreadyHashed = NewValidationError("password", "already_hashed", "password already hashed")
 )
@@ -1,6 +1,7 @@
 package domain_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
@@ -27,6 +28,34 @@ func TestUser_Email(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "Display name",
+			email: &domain.Email{
+				Value: "John Doe <john@email.com>",
+			},
+			expectError: true,
+		},
+		{
+			name: "Consecutive dots",
+			email: &domain.Email{
+				Value: "john..doe@email.com",
+			},
+			expectError: true,
+		},
+		{
+			name: "Dotless domain",
+			email: &domain.Email{
+				Value: "john@localhost",
+			},
+			expectError: true,
+		},
+		{
+			name: "Internationalized",
+			email: &domain.Email{
+				Value: "josé@exämple.com",
+			},
+			expectError: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -59,6 +88,22 @@ func TestUser_Password(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "Password at the bcrypt limit",
+			password: &domain.Password{
+				Value:    strings.Repeat("a", domain.MaxPasswordBytes),
+				IsHashed: false,
+			},
+			expectError: false,
+		},
+		{
+			name: "Multibyte password over the bcrypt limit",
+			password: &domain.Password{
+				Value:    strings.Repeat("é", 40),
+				IsHashed: false,
+			},
+			expectError: true,
+		},
 		{
 			name: "Password already hashed",
 			password: &domain.Password{
@@ -118,3 +163,211 @@ func TestUser_User(t *testing.T) {
 		assert.Equal(t, tt.expectError, err != nil)
 	}
 }
+
+func TestUser_Username(t *testing.T) {
+	tests := []struct {
+		expectedErrors []error
+		name           string
+		username       string
+	}{
+		{
+			name:     "Valid username",
+			username: "valid.user-name_1",
+		},
+		{
+			name:           "Empty username",
+			username:       "",
+			expectedErrors: []error{domain.ErrUsernameRequired},
+		},
+		{
+			name:     "Unicode letters",
+			username: "józef_ßtraße",
+		},
+		{
+			name:           "Too short",
+			username:       "ab",
+			expectedErrors: []error{domain.ErrUsernameTooShort},
+		},
+		{
+			name:           "Too long",
+			username:       strings.Repeat("a", domain.UsernameMaxLength+1),
+			expectedErrors: []error{domain.ErrUsernameTooLong},
+		},
+		{
+			name:           "Invalid characters",
+			username:       "user name!",
+			expectedErrors: []error{domain.ErrUsernameInvalidCharacters},
+		},
+		{
+			name:           "Reserved",
+			username:       "Admin",
+			expectedErrors: []error{domain.ErrUsernameReserved},
+		},
+		{
+			name:           "Reserved look-alike",
+			username:       "аdmіn",
+			expectedErrors: []error{domain.ErrUsernameReserved},
+		},
+		{
+			name:           "Several violations",
+			username:       "a!",
+			expectedErrors: []error{domain.ErrUsernameTooShort, domain.ErrUsernameInvalidCharacters},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := domain.ValidateUsername(tt.username)
+			if len(tt.expectedErrors) == 0 {
+				assert.NoError(t, err)
+			}
+			for _, expectedError := range tt.expectedErrors {
+				assert.ErrorIs(t, err, expectedError)
+			}
+		})
+	}
+}
+
+func TestUser_ValidateAggregatesErrors(t *testing.T) {
+	user := &domain.User{
+		Username: "root",
+		Email: &domain.Email{
+			Value: "invalid_email.com",
+		},
+		Password: &domain.Password{
+			Value: "short",
+		},
+	}
+
+	err := user.Validate()
+
+	var validationErrs domain.ValidationErrors
+	assert.ErrorAs(t, err, &validationErrs)
+	assert.Len(t, validationErrs, 3)
+	assert.ErrorIs(t, err, domain.ErrValidationFailed)
+	assert.ErrorIs(t, err, domain.ErrEmailInvalid)
+	assert.ErrorIs(t, err, domain.ErrPasswordTooShort)
+	assert.ErrorIs(t, err, domain.ErrUsernameReserved)
+}
+
+func TestUser_ValidateSingleErrorIsNotAggregate(t *testing.T) {
+	user := &domain.User{
+		Username: "valid_user",
+		Email: &domain.Email{
+			Value: "invalid_email.com",
+		},
+		Password: &domain.Password{
+			Value: "valid_password",
+		},
+	}
+
+	err := user.Validate()
+	assert.ErrorIs(t, err, domain.ErrEmailInvalid)
+	assert.NotErrorIs(t, err, domain.ErrValidationFailed)
+}
+
+func TestUser_RegisterUserRule(t *testing.T) {
+	errBlockedUsername := domain.NewValidationError("username", "blocked", "username is blocked")
+	domain.RegisterUserRule(func(user *domain.User) error {
+		if user.Username == "blocked_user" {
+			return errBlockedUsername
+		}
+		return nil
+	})
+
+	tests := []struct {
+		expectedError error
+		name          string
+		username      string
+	}{
+		{
+			name:     "Rule passes",
+			username: "allowed_user",
+		},
+		{
+			name:          "Rule fails",
+			username:      "blocked_user",
+			expectedError: errBlockedUsername,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &domain.User{
+				Username: tt.username,
+				Email: &domain.Email{
+					Value: "valid@email.com",
+				},
+				Password: &domain.Password{
+					Value: "valid_password",
+				},
+			}
+
+			err := user.Validate()
+			if tt.expectedError == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestUser_UsernameSkeleton(t *testing.T) {
+	tests := []struct {
+		name          string
+		username      string
+		other         string
+		expectedEqual bool
+	}{
+		{
+			name:          "Case variants",
+			username:      "TestUser",
+			other:         "testuser",
+			expectedEqual: true,
+		},
+		{
+			name:          "Cyrillic look-alike",
+			username:      "аpple",
+			other:         "apple",
+			expectedEqual: true,
+		},
+		{
+			name:          "Greek look-alike",
+			username:      "οpεn",
+			other:         "open",
+			expectedEqual: true,
+		},
+		{
+			name:          "Digit look-alike",
+			username:      "g00gle",
+			other:         "google",
+			expectedEqual: true,
+		},
+		{
+			name:          "Fullwidth",
+			username:      "ｕｓｅｒ",
+			other:         "user",
+			expectedEqual: true,
+		},
+		{
+			name:          "Composed and decomposed accents",
+			username:      "josé",
+			other:         "josé",
+			expectedEqual: true,
+		},
+		{
+			name:          "Different usernames",
+			username:      "alice",
+			other:         "alicia",
+			expectedEqual: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal := domain.UsernameSkeleton(tt.username) == domain.UsernameSkeleton(tt.other)
+			assert.Equal(t, tt.expectedEqual, equal)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	UsernameMinLength = 3
+	UsernameMaxLength = 32
+)
+
+var reservedUsernames = []string{
+	"admin",
+	"administrator",
+	"root",
+	"system",
+	"support",
+	"api",
+	"me",
+}
+
+var reservedSkeletons = func() map[string]bool {
+	skeletons := make(map[string]bool, len(reservedUsernames))
+	for _, username := range reservedUsernames {
+		skeletons[UsernameSkeleton(username)] = true
+	}
+	return skeletons
+}()
+
+// NormalizeUsername returns the NFKC form of username, which folds
+// compatibility characters such as fullwidth letters into their plain form
+// while keeping the case chosen by the user for display.
+func NormalizeUsername(username string) string {
+	return norm.NFKC.String(strings.TrimSpace(username))
+}
+
+// UsernameSkeleton derives the key used to detect look-alike usernames, in
+// the spirit of the UTS #39 skeleton: NFKC, case folding, NFD, then mapping
+// every confusable character to a shared prototype. Two usernames with the
+// same skeleton are considered the same account name.
+func UsernameSkeleton(username string) string {
+	folded := cases.Fold().String(NormalizeUsername(username))
+
+	var skeleton strings.Builder
+	for _, r := range norm.NFD.String(folded) {
+		if prototype, ok := confusablePrototypes[r]; ok {
+			skeleton.WriteString(prototype)
+			continue
+		}
+		skeleton.WriteRune(r)
+	}
+	return norm.NFD.String(skeleton.String())
+}
+
+func ValidateUsername(username string) error {
+	var errs ValidationErrors
+	length := utf8.RuneCountInString(username)
+
+	switch {
+	case length == 0:
+		return ErrUsernameRequired
+	case length < UsernameMinLength:
+		errs = append(errs, ErrUsernameTooShort)
+	case length > UsernameMaxLength:
+		errs = append(errs, ErrUsernameTooLong)
+	}
+
+	if strings.IndexFunc(username, isDisallowedUsernameRune) >= 0 {
+		errs = append(errs, ErrUsernameInvalidCharacters)
+	}
+
+	if reservedSkeletons[UsernameSkeleton(username)] {
+		errs = append(errs, ErrUsernameReserved)
+	}
+
+	return errs.OrNil()
+}
+
+func isDisallowedUsernameRune(r rune) bool {
+	switch {
+	case unicode.IsLetter(r), unicode.IsDigit(r), unicode.Is(unicode.Mn, r):
+		return false
+	case r == '_', r == '.', r == '-':
+		return false
+	}
+	return true
+}
+
+var (
+	ErrUsernameRequired          = NewValidationError("username", "required", "username is required")
+	ErrUsernameTooShort          = NewValidationError("username", "too_short", "username is too short")
+	ErrUsernameTooLong           = NewValidationError("username", "too_long", "username is too long")
+	ErrUsernameInvalidCharacters = NewValidationError("username", "invalid_characters", "username may only contain letters, digits, '_', '.' and '-'")
+	ErrUsernameReserved          = NewValidationError("username", "reserved", "username is reserved")
+)
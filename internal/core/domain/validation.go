@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ValidationError is a rule violation on a single field. Code is a short,
+// stable identifier such as "too_short", meant for clients rather than humans.
+type ValidationError struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+func NewValidationError(field, code, message string) *ValidationError {
+	return &ValidationError{
+		Field:   field,
+		Code:    code,
+		Message: message,
+	}
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// ValidationErrors aggregates every violation found while validating a value.
+// errors.Is and errors.As see each violation individually. It also matches
+// ErrValidationFailed, but only when it holds more than one violation, so a
+// single failure keeps being reported by its own sentinel.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Field+": "+err.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func (e ValidationErrors) Is(target error) bool {
+	return target == ErrValidationFailed && len(e) > 1
+}
+
+// Append flattens err into e. Errors that are not validation errors are kept
+// as a violation without a field so that custom rules cannot be ignored.
+func (e ValidationErrors) Append(err error) ValidationErrors {
+	if err == nil {
+		return e
+	}
+
+	var validationErrs ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return append(e, validationErrs...)
+	}
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return append(e, validationErr)
+	}
+	return append(e, NewValidationError("", "invalid", err.Error()))
+}
+
+// OrNil returns nil when there are no violations, avoiding a non-nil error
+// interface that holds an empty slice.
+func (e ValidationErrors) OrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+var ErrValidationFailed = errors.New("validation failed")
+
+// UserRule is an application-specific check run by User.Validate after the
+// built-in rules. It should return a *ValidationError, ValidationErrors or nil.
+type UserRule func(user *User) error
+
+var (
+	userRules   []UserRule
+	userRulesMu sync.RWMutex
+)
+
+func RegisterUserRule(rule UserRule) {
+	userRulesMu.Lock()
+	defer userRulesMu.Unlock()
+
+	userRules = append(userRules, rule)
+}
+
+func registeredUserRules() []UserRule {
+	userRulesMu.RLock()
+	defer userRulesMu.RUnlock()
+
+	return append([]UserRule(nil), userRules...)
+}
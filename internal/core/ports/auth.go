@@ -0,0 +1,12 @@
+package ports
+
+import "github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+
+// TokenService issues the access tokens that authenticate HTTP requests,
+// whether sent as bearer tokens or carried by the session cookie.
+type TokenService interface {
+	Issue(principal *domain.Principal) (*domain.AccessToken, error)
+	// Verify returns the principal the token was issued to, or an error
+	// when the token is malformed, forged or expired.
+	Verify(token string) (*domain.Principal, error)
+}
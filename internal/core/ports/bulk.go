@@ -0,0 +1,31 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+)
+
+// UserRecordReader streams import rows. Read returns io.EOF after the last
+// row, and a *domain.ImportRowError for a row that cannot be parsed; reading
+// may continue after such an error. Any other error is fatal.
+type UserRecordReader interface {
+	Read() (*domain.ImportRecord, error)
+}
+
+// UserRecordWriter streams exported users. Flush must be called once every
+// user has been written.
+type UserRecordWriter interface {
+	Write(user *domain.UserProfile) error
+	Flush() error
+}
+
+// Inviter asks a user created without a password to choose one.
+type Inviter interface {
+	Invite(ctx context.Context, user *domain.UserProfile) error
+}
+
+type UsersBulkService interface {
+	Import(ctx context.Context, reader UserRecordReader, options domain.ImportOptions) (*domain.ImportReport, error)
+	Export(ctx context.Context, writer UserRecordWriter) (int, error)
+}
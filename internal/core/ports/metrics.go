@@ -0,0 +1,17 @@
+package ports
+
+import "time"
+
+// Metrics records what the core does, leaving the choice of a metrics
+// backend to the adapters. Implementations must be safe for concurrent use.
+type Metrics interface {
+	// RecordLogin counts a login attempt by outcome, such as "success" or
+	// the reason it failed. There is no lockout after failed attempts, so
+	// no outcome counts lockouts; "account_disabled" only counts logins to
+	// accounts an admin disabled.
+	RecordLogin(outcome string)
+	RecordSignup(outcome string)
+	// ObserveHashing records how long a Hasher operation, "hash" or
+	// "compare", took.
+	ObserveHashing(operation string, duration time.Duration)
+}
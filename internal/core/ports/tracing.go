@@ -0,0 +1,20 @@
+package ports
+
+import "context"
+
+// Tracer delimits the units of work of a request, leaving the choice of a
+// tracing backend to the adapters. The context returned by Start carries
+// the span, so that spans started from it become its children.
+type Tracer interface {
+	Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span)
+}
+
+type Span interface {
+	// End finishes the span and marks it as failed when err is not nil.
+	End(err error)
+}
+
+type Attribute struct {
+	Value any
+	Key   string
+}
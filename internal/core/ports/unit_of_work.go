@@ -0,0 +1,17 @@
+package ports
+
+import "context"
+
+// Transaction exposes repositories bound to a single unit of work. They must
+// not be used after the function passed to UnitOfWork.Do returns.
+type Transaction interface {
+	Users() UsersRepository
+}
+
+// UnitOfWork runs fn atomically: every write made through tx is committed if
+// fn returns nil and discarded otherwise. Implementations may call fn more
+// than once when the backend reports a retryable conflict, so fn must not
+// have side effects outside of tx.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(tx Transaction) error) error
+}
@@ -1,13 +1,24 @@
 package ports
 
 import (
+	"context"
+
 	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
 )
 
 type UsersRepository interface {
-	FindByEmail(email string) (*domain.User, error)
-	FindByUsername(username string) (*domain.User, error)
-	Create(user *domain.User) error
+	FindByEmail(ctx context.Context, email string) (*domain.User, error)
+	FindByUsername(ctx context.Context, username string) (*domain.User, error)
+	FindByID(ctx context.Context, id uint) (*domain.User, error)
+	// List returns at most limit users with an ID greater than after,
+	// ordered by ID.
+	List(ctx context.Context, after uint, limit int) ([]*domain.User, error)
+	Create(ctx context.Context, user *domain.User) error
+	// Update persists the password, role and disabled flag of an existing
+	// user. Usernames and emails are immutable.
+	Update(ctx context.Context, user *domain.User) error
+	// CreateBatch creates every user or none and assigns their IDs.
+	CreateBatch(ctx context.Context, users []*domain.User) error
 }
 
 type Hasher interface {
@@ -16,6 +27,19 @@ type Hasher interface {
 }
 
 type UsersService interface {
-	Login(email, password string) (*domain.LoginResponse, error)
-	Signup(payload *domain.SignupPayload) (*domain.SignupResponse, error)
+	Login(ctx context.Context, email, password string) (*domain.LoginResponse, error)
+	Signup(ctx context.Context, payload *domain.SignupPayload) (*domain.SignupResponse, error)
+	Me(ctx context.Context) (*domain.UserProfile, error)
+	List(ctx context.Context, query domain.ListUsersQuery) (*domain.UsersPage, error)
+}
+
+// UsersAdminService holds the operations reserved to admins. Users are
+// identified by ID, email or username.
+type UsersAdminService interface {
+	CreateUser(ctx context.Context, payload *domain.SignupPayload, role domain.Role) (*domain.UserProfile, error)
+	GetUser(ctx context.Context, identifier string) (*domain.UserProfile, error)
+	DisableUser(ctx context.Context, identifier string) (*domain.UserProfile, error)
+	ResetPassword(ctx context.Context, identifier, password string) (*domain.UserProfile, error)
+	SetRole(ctx context.Context, identifier string, role domain.Role) (*domain.UserProfile, error)
+	List(ctx context.Context, query domain.ListUsersQuery) (*domain.UsersPage, error)
 }
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+)
+
+const (
+	DefaultImportBatchSize = 500
+	exportPageSize         = 500
+)
+
+type Bulk struct {
+	userRepository ports.UsersRepository
+	unitOfWork     ports.UnitOfWork
+	inviter        ports.Inviter
+	logger         *slog.Logger
+}
+
+// NewBulkService returns the import and export service. inviter may be nil,
+// in which case every imported row needs a password hash.
+func NewBulkService(repository ports.UsersRepository, unitOfWork ports.UnitOfWork, inviter ports.Inviter, logger *slog.Logger) *Bulk {
+	return &Bulk{
+		userRepository: repository,
+		unitOfWork:     unitOfWork,
+		inviter:        inviter,
+		logger:         logger,
+	}
+}
+
+type importRow struct {
+	user *domain.User
+	line int
+}
+
+// importRun holds the state of a single Import call. Emails and skeletons
+// already seen in the file are tracked so that duplicates within it are
+// caught even in a dry run, where nothing reaches the repository.
+type importRun struct {
+	report    *domain.ImportReport
+	emails    map[string]int
+	skeletons map[string]int
+	options   domain.ImportOptions
+}
+
+// fail records a failed row and reports whether the import must stop.
+func (r *importRun) fail(line int, err error) bool {
+	r.report.Failed++
+	r.report.Errors = append(r.report.Errors, &domain.ImportRowError{Line: line, Err: err})
+	return !r.options.ContinueOnError
+}
+
+// Import validates every row with the domain rules and creates the users in
+// batches, each in its own transaction.
+func (s *Bulk) Import(ctx context.Context, reader ports.UserRecordReader, options domain.ImportOptions) (*domain.ImportReport, error) {
+	if err := requireAdmin(ctx, s.userRepository); err != nil {
+		return nil, err
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultImportBatchSize
+	}
+
+	run := &importRun{
+		report:    &domain.ImportReport{},
+		emails:    map[string]int{},
+		skeletons: map[string]int{},
+		options:   options,
+	}
+	defer func() {
+		s.logger.InfoContext(ctx, "import finished",
+			slog.Int("created", run.report.Created),
+			slog.Int("invited", run.report.Invited),
+			slog.Int("failed", run.report.Failed),
+			slog.Bool("dry_run", options.DryRun),
+		)
+	}()
+
+	var batch []*importRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var rowErr *domain.ImportRowError
+		if errors.As(err, &rowErr) {
+			if run.fail(rowErr.Line, rowErr.Err) {
+				return run.report, s.stop(ctx, run, batch)
+			}
+			continue
+		}
+		if err != nil {
+			return run.report, err
+		}
+
+		row, err := s.prepare(run, record)
+		if err != nil {
+			if run.fail(record.Line, err) {
+				return run.report, s.stop(ctx, run, batch)
+			}
+			continue
+		}
+
+		batch = append(batch, row)
+		if len(batch) == options.BatchSize {
+			if err := s.flush(ctx, run, batch); err != nil {
+				return run.report, err
+			}
+			batch = nil
+		}
+	}
+
+	return run.report, s.flush(ctx, run, batch)
+}
+
+// stop commits the rows preceding the failure before reporting it.
+func (s *Bulk) stop(ctx context.Context, run *importRun, batch []*importRow) error {
+	if err := s.flush(ctx, run, batch); err != nil {
+		return err
+	}
+	return ErrImportStopped
+}
+
+func (s *Bulk) prepare(run *importRun, record *domain.ImportRecord) (*importRow, error) {
+	if err := record.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidUserPayload, err)
+	}
+	if record.PasswordHash == "" && s.inviter == nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidUserPayload, domain.ErrPasswordHashRequired)
+	}
+
+	user := record.ToDomainUser()
+	normalizedEmail, err := user.Email.Normalized()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidUserPayload, err)
+	}
+	user.Email.Value = normalizedEmail
+
+	if line, ok := run.emails[user.Email.Value]; ok {
+		return nil, fmt.Errorf("%w: same as line %d", ErrEmailAlreadyTaken, line)
+	}
+	skeleton := domain.UsernameSkeleton(user.Username)
+	if line, ok := run.skeletons[skeleton]; ok {
+		return nil, fmt.Errorf("%w: same as line %d", ErrUsernameAlreadyTaken, line)
+	}
+	run.emails[user.Email.Value] = record.Line
+	run.skeletons[skeleton] = record.Line
+
+	return &importRow{user: user, line: record.Line}, nil
+}
+
+// flush creates the users of batch that do not conflict with existing ones.
+// If the batch insert still hits a unique violation, because of a
+// concurrent signup for instance, the rows are retried one by one so that
+// the conflict is attributed to its row.
+func (s *Bulk) flush(ctx context.Context, run *importRun, batch []*importRow) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	var created []*importRow
+	var conflicts []*domain.ImportRowError
+	err := s.unitOfWork.Do(ctx, func(tx ports.Transaction) error {
+		created, conflicts = nil, nil
+		for _, row := range batch {
+			err := checkIfUserAlreadyExists(ctx, tx.Users(), row.user)
+			if errors.Is(err, ErrEmailAlreadyTaken) || errors.Is(err, ErrUsernameAlreadyTaken) {
+				conflicts = append(conflicts, &domain.ImportRowError{Line: row.line, Err: err})
+				if !run.options.ContinueOnError {
+					break
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			created = append(created, row)
+		}
+
+		if run.options.DryRun || len(created) == 0 {
+			return nil
+		}
+		users := make([]*domain.User, 0, len(created))
+		for _, row := range created {
+			users = append(users, row.user)
+		}
+		return tx.Users().CreateBatch(ctx, users)
+	})
+	if errors.Is(err, ErrEmailAlreadyTaken) || errors.Is(err, ErrUsernameAlreadyTaken) {
+		created, err = s.createOneByOne(ctx, run, created)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.invite(ctx, run, created)
+
+	for _, conflict := range conflicts {
+		if run.fail(conflict.Line, conflict.Err) {
+			return ErrImportStopped
+		}
+	}
+	return nil
+}
+
+func (s *Bulk) createOneByOne(ctx context.Context, run *importRun, rows []*importRow) ([]*importRow, error) {
+	var created []*importRow
+	for _, row := range rows {
+		err := s.unitOfWork.Do(ctx, func(tx ports.Transaction) error {
+			return tx.Users().Create(ctx, row.user)
+		})
+		if errors.Is(err, ErrEmailAlreadyTaken) || errors.Is(err, ErrUsernameAlreadyTaken) {
+			if run.fail(row.line, err) {
+				return created, ErrImportStopped
+			}
+			continue
+		}
+		if err != nil {
+			return created, err
+		}
+		created = append(created, row)
+	}
+	return created, nil
+}
+
+func (s *Bulk) invite(ctx context.Context, run *importRun, rows []*importRow) {
+	for _, row := range rows {
+		if row.user.Password.Value != domain.UnusablePassword {
+			run.report.Created++
+			continue
+		}
+		if run.options.DryRun {
+			run.report.Invited++
+			continue
+		}
+
+		if err := s.inviter.Invite(ctx, NewUserProfile(row.user)); err != nil {
+			// The user exists by now; only the invitation is missing.
+			s.logger.WarnContext(ctx, "invite failed",
+				slog.Uint64("user_id", uint64(row.user.ID)),
+				slog.Any("error", err),
+			)
+			run.report.Failed++
+			run.report.Errors = append(run.report.Errors, &domain.ImportRowError{
+				Line: row.line,
+				Err:  fmt.Errorf("%w: %w", ErrInviteFailed, err),
+			})
+			continue
+		}
+		run.report.Invited++
+	}
+}
+
+// Export streams every user, without password hashes, ordered by ID, and
+// returns how many were written.
+func (s *Bulk) Export(ctx context.Context, writer ports.UserRecordWriter) (int, error) {
+	if err := requireAdmin(ctx, s.userRepository); err != nil {
+		return 0, err
+	}
+
+	exported := 0
+	var after uint
+	for {
+		users, err := s.userRepository.List(ctx, after, exportPageSize)
+		if err != nil {
+			return exported, err
+		}
+		for _, user := range users {
+			if err := writer.Write(NewUserProfile(user)); err != nil {
+				return exported, err
+			}
+			exported++
+		}
+		if len(users) < exportPageSize {
+			break
+		}
+		after = users[len(users)-1].ID
+	}
+	return exported, writer.Flush()
+}
+
+var (
+	ErrImportStopped = errors.New("import stopped at the first failing row")
+	ErrInviteFailed  = errors.New("user created but invite failed")
+)
@@ -0,0 +1,203 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	memoryRepository "github.com/raphael-foliveira/go-table-tests/internal/adapters/repository/memory"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"github.com/raphael-foliveira/go-table-tests/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var bcryptHash = "$2a$10$" + strings.Repeat("a", 53)
+
+type recordsReader struct {
+	records []*domain.ImportRecord
+}
+
+func (r *recordsReader) Read() (*domain.ImportRecord, error) {
+	if len(r.records) == 0 {
+		return nil, io.EOF
+	}
+	record := r.records[0]
+	r.records = r.records[1:]
+	if record.Email == "malformed" {
+		return nil, &domain.ImportRowError{Line: record.Line, Err: errors.New("malformed")}
+	}
+	return record, nil
+}
+
+func newRecords(records ...*domain.ImportRecord) *recordsReader {
+	for i, record := range records {
+		record.Line = i + 2
+	}
+	return &recordsReader{records: records}
+}
+
+func setUpBulk(t *testing.T, withInviter bool) (*service.Bulk, *memoryRepository.Users, *mocks.MockInviter, context.Context) {
+	users := memoryRepository.NewUsers()
+	admin := domain.ContextWithPrincipal(context.Background(), &domain.Principal{Role: domain.RoleAdmin})
+	require.NoError(t, users.Create(admin, &domain.User{
+		Username: "existing",
+		Email:    &domain.Email{Value: "existing@user.com"},
+		Password: &domain.Password{Value: bcryptHash, IsHashed: true},
+		Role:     domain.RoleUser,
+	}))
+
+	if !withInviter {
+		return service.NewBulkService(users, memoryRepository.NewUnitOfWork(users), nil, logging.Discard()), users, nil, admin
+	}
+	inviterMock := mocks.NewMockInviter(t)
+	return service.NewBulkService(users, memoryRepository.NewUnitOfWork(users), inviterMock, logging.Discard()), users, inviterMock, admin
+}
+
+func TestBulkServiceImport_Authorization(t *testing.T) {
+	bulkService, _, _, _ := setUpBulk(t, false)
+	ctx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: 1, Role: domain.RoleUser})
+
+	_, err := bulkService.Import(ctx, newRecords(), domain.ImportOptions{})
+	assert.ErrorIs(t, err, service.ErrForbidden)
+
+	_, err = bulkService.Export(context.Background(), nil)
+	assert.ErrorIs(t, err, service.ErrUnauthenticated)
+}
+
+func TestBulkServiceImport(t *testing.T) {
+	tests := []struct {
+		name            string
+		records         []*domain.ImportRecord
+		options         domain.ImportOptions
+		expectedErr     error
+		expectedReport  domain.ImportReport
+		expectedLines   []int
+		expectedCreated []string
+	}{
+		{
+			name: "Creates every valid row",
+			records: []*domain.ImportRecord{
+				{Email: "First@User.com", Username: "first", PasswordHash: bcryptHash},
+				{Email: "second@user.com", Username: "second", PasswordHash: bcryptHash, Role: domain.RoleAdmin},
+				{Email: "third@user.com", Username: "third", PasswordHash: bcryptHash},
+			},
+			options:         domain.ImportOptions{BatchSize: 2},
+			expectedReport:  domain.ImportReport{Created: 3},
+			expectedCreated: []string{"first@user.com", "second@user.com", "third@user.com"},
+		},
+		{
+			name: "Stops at the first failing row",
+			records: []*domain.ImportRecord{
+				{Email: "first@user.com", Username: "first", PasswordHash: bcryptHash},
+				{Email: "second@user.com", Username: "second", PasswordHash: "plain"},
+				{Email: "third@user.com", Username: "third", PasswordHash: bcryptHash},
+			},
+			expectedErr:     service.ErrImportStopped,
+			expectedReport:  domain.ImportReport{Created: 1, Failed: 1},
+			expectedLines:   []int{3},
+			expectedCreated: []string{"first@user.com"},
+		},
+		{
+			name: "Continues on error",
+			records: []*domain.ImportRecord{
+				{Email: "invalid", Username: "first", PasswordHash: bcryptHash},
+				{Email: "malformed"},
+				{Email: "existing@user.com", Username: "second", PasswordHash: bcryptHash},
+				{Email: "third@user.com", Username: "existing", PasswordHash: bcryptHash},
+				{Email: "fourth@user.com", Username: "fourth", PasswordHash: bcryptHash},
+				{Email: "Fourth@user.com", Username: "fifth", PasswordHash: bcryptHash},
+				{Email: "sixth@user.com", Username: "sixth"},
+				{Email: "seventh@user.com", Username: "seventh", PasswordHash: bcryptHash, Role: "root"},
+			},
+			options:         domain.ImportOptions{ContinueOnError: true},
+			expectedReport:  domain.ImportReport{Created: 1, Failed: 7},
+			expectedLines:   []int{2, 3, 7, 8, 9, 4, 5},
+			expectedCreated: []string{"fourth@user.com"},
+		},
+		{
+			name: "Dry run",
+			records: []*domain.ImportRecord{
+				{Email: "first@user.com", Username: "first", PasswordHash: bcryptHash},
+				{Email: "existing@user.com", Username: "second", PasswordHash: bcryptHash},
+			},
+			options:        domain.ImportOptions{DryRun: true, ContinueOnError: true},
+			expectedReport: domain.ImportReport{Created: 1, Failed: 1},
+			expectedLines:  []int{3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bulkService, users, _, admin := setUpBulk(t, false)
+
+			report, err := bulkService.Import(admin, newRecords(tt.records...), tt.options)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			var lines []int
+			for _, rowErr := range report.Errors {
+				lines = append(lines, rowErr.Line)
+			}
+			assert.Equal(t, tt.expectedLines, lines)
+			report.Errors = nil
+			assert.Equal(t, tt.expectedReport, *report)
+
+			created, err := users.List(admin, 1, 100)
+			require.NoError(t, err)
+			var emails []string
+			for _, user := range created {
+				emails = append(emails, user.Email.Value)
+			}
+			assert.Equal(t, tt.expectedCreated, emails)
+		})
+	}
+}
+
+func TestBulkServiceImport_Invites(t *testing.T) {
+	bulkService, users, inviterMock, admin := setUpBulk(t, true)
+	inviterMock.EXPECT().Invite(mock.Anything, mock.MatchedBy(func(user *domain.UserProfile) bool {
+		return user.Email == "first@user.com"
+	})).Return(nil).Once()
+	inviterMock.EXPECT().Invite(mock.Anything, mock.MatchedBy(func(user *domain.UserProfile) bool {
+		return user.Email == "second@user.com"
+	})).Return(errors.New("mailer down")).Once()
+
+	report, err := bulkService.Import(admin, newRecords(
+		&domain.ImportRecord{Email: "first@user.com", Username: "first"},
+		&domain.ImportRecord{Email: "second@user.com", Username: "second"},
+		&domain.ImportRecord{Email: "third@user.com", Username: "third", PasswordHash: bcryptHash},
+	), domain.ImportOptions{ContinueOnError: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Invited)
+	assert.Equal(t, 1, report.Failed)
+	require.Len(t, report.Errors, 1)
+	assert.ErrorIs(t, report.Errors[0], service.ErrInviteFailed)
+
+	invited, err := users.FindByEmail(admin, "first@user.com")
+	require.NoError(t, err)
+	assert.Equal(t, domain.UnusablePassword, invited.Password.Value)
+}
+
+func TestBulkServiceExport(t *testing.T) {
+	bulkService, _, _, admin := setUpBulk(t, false)
+	writerMock := mocks.NewMockUserRecordWriter(t)
+	writerMock.EXPECT().Write(mock.MatchedBy(func(user *domain.UserProfile) bool {
+		return user.Email == "existing@user.com" && user.Username == "existing"
+	})).Return(nil).Once()
+	writerMock.EXPECT().Flush().Return(nil).Once()
+
+	exported, err := bulkService.Export(admin, writerMock)
+	require.NoError(t, err)
+	assert.Equal(t, 1, exported)
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"time"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+)
+
+const (
+	HashOperationHash    = "hash"
+	HashOperationCompare = "compare"
+)
+
+type timedHasher struct {
+	hasher  ports.Hasher
+	metrics ports.Metrics
+}
+
+// NewTimedHasher wraps hasher so that the duration of every call is
+// recorded, which shows how the hashing cost behaves under load.
+func NewTimedHasher(hasher ports.Hasher, metrics ports.Metrics) ports.Hasher {
+	return &timedHasher{
+		hasher:  hasher,
+		metrics: metrics,
+	}
+}
+
+func (h *timedHasher) Hash(password string) (string, error) {
+	start := time.Now()
+	defer func() { h.metrics.ObserveHashing(HashOperationHash, time.Since(start)) }()
+	return h.hasher.Hash(password)
+}
+
+func (h *timedHasher) Compare(givenPassword, hashedPassword string) bool {
+	start := time.Now()
+	defer func() { h.metrics.ObserveHashing(HashOperationCompare, time.Since(start)) }()
+	return h.hasher.Compare(givenPassword, hashedPassword)
+}
@@ -1,8 +1,12 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
 
 	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
 	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
@@ -11,68 +15,163 @@ import (
 type Users struct {
 	userRepository ports.UsersRepository
 	hasher         ports.Hasher
+	unitOfWork     ports.UnitOfWork
+	logger         *slog.Logger
+	metrics        ports.Metrics
+	tracer         ports.Tracer
 }
 
-func NewUsersService(repository ports.UsersRepository, hasher ports.Hasher) *Users {
+func NewUsersService(repository ports.UsersRepository, hasher ports.Hasher, unitOfWork ports.UnitOfWork, logger *slog.Logger, metrics ports.Metrics, tracer ports.Tracer) *Users {
 	return &Users{
 		userRepository: repository,
 		hasher:         hasher,
+		unitOfWork:     unitOfWork,
+		logger:         logger,
+		metrics:        metrics,
+		tracer:         tracer,
 	}
 }
 
-func (s *Users) Login(email, password string) (*domain.LoginResponse, error) {
-	foundUser, err := s.userRepository.FindByEmail(email)
+// Outcomes of Login and Signup, as recorded by ports.Metrics.
+const (
+	OutcomeSuccess              = "success"
+	OutcomeError                = "error"
+	LoginOutcomeInvalidEmail    = "invalid_email"
+	LoginOutcomeUnknownEmail    = "unknown_email"
+	LoginOutcomeWrongPassword   = "wrong_password"
+	LoginOutcomeAccountDisabled = "account_disabled"
+	SignupOutcomeEmailTaken     = "email_taken"
+	SignupOutcomeUsernameTaken  = "username_taken"
+	SignupOutcomeInvalidPayload = "invalid_payload"
+)
+
+func (s *Users) Login(ctx context.Context, email, password string) (response *domain.LoginResponse, err error) {
+	ctx, span := s.tracer.Start(ctx, "Users.Login")
+	defer func() { span.End(err) }()
+
+	normalizedEmail, err := (&domain.Email{Value: email}).Normalized()
 	if err != nil {
+		s.loginFailed(ctx, LoginOutcomeInvalidEmail, nil)
+		return nil, ErrInvalidCredentials
+	}
+
+	foundUser, err := s.userRepository.FindByEmail(ctx, normalizedEmail)
+	if err != nil {
+		s.metrics.RecordLogin(OutcomeError)
 		return nil, err
 	}
 
-	ok := s.hasher.Compare(password, foundUser.Password.Value)
-	if foundUser == nil || !ok {
+	if foundUser == nil {
+		s.loginFailed(ctx, LoginOutcomeUnknownEmail, nil)
+		return nil, ErrInvalidCredentials
+	}
+	if !s.comparePassword(ctx, password, foundUser.Password.Value) {
+		s.loginFailed(ctx, LoginOutcomeWrongPassword, foundUser)
 		return nil, ErrInvalidCredentials
 	}
+	if foundUser.Disabled {
+		s.loginFailed(ctx, LoginOutcomeAccountDisabled, foundUser)
+		return nil, ErrAccountDisabled
+	}
 
+	s.metrics.RecordLogin(OutcomeSuccess)
+	s.logger.InfoContext(ctx, "login succeeded", slog.Uint64("user_id", uint64(foundUser.ID)))
 	return &domain.LoginResponse{
 		Username: foundUser.Username,
 		Email:    foundUser.Email.Value,
+		Role:     foundUser.Role,
+		UserID:   foundUser.ID,
 	}, nil
 }
 
+func (s *Users) loginFailed(ctx context.Context, reason string, user *domain.User) {
+	s.metrics.RecordLogin(reason)
+	attrs := []any{slog.String("reason", reason)}
+	if user != nil {
+		attrs = append(attrs, slog.Uint64("user_id", uint64(user.ID)))
+	}
+	s.logger.InfoContext(ctx, "login failed", attrs...)
+}
+
 var ErrInvalidCredentials = errors.New("invalid credentials")
 
 func NewSignupResponse(user *domain.User) *domain.SignupResponse {
 	return &domain.SignupResponse{
 		Username: user.Username,
 		Email:    user.Email.Value,
+		Role:     user.Role,
 		ID:       user.ID,
 	}
 }
 
-func (s *Users) Signup(payload *domain.SignupPayload) (*domain.SignupResponse, error) {
+func (s *Users) Signup(ctx context.Context, payload *domain.SignupPayload) (response *domain.SignupResponse, err error) {
+	ctx, span := s.tracer.Start(ctx, "Users.Signup")
+	defer func() { span.End(err) }()
+
+	user, err := s.create(ctx, payload, domain.RoleUser)
+	s.metrics.RecordSignup(signupOutcome(err))
+	if err != nil {
+		return nil, err
+	}
+	return NewSignupResponse(user), nil
+}
+
+func signupOutcome(err error) string {
+	switch {
+	case err == nil:
+		return OutcomeSuccess
+	case errors.Is(err, ErrEmailAlreadyTaken):
+		return SignupOutcomeEmailTaken
+	case errors.Is(err, ErrUsernameAlreadyTaken):
+		return SignupOutcomeUsernameTaken
+	case errors.Is(err, ErrInvalidUserPayload):
+		return SignupOutcomeInvalidPayload
+	}
+	return OutcomeError
+}
+
+func (s *Users) create(ctx context.Context, payload *domain.SignupPayload, role domain.Role) (*domain.User, error) {
 	userToCreate := payload.ToDomainUser()
+	userToCreate.Role = role
 	if err := userToCreate.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrInvalidUserPayload, err)
 	}
 
-	err := s.checkIfUserAlreadyExists(userToCreate)
+	normalizedEmail, err := userToCreate.Email.Normalized()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrInvalidUserPayload, err)
 	}
+	userToCreate.Email.Value = normalizedEmail
 
-	err = s.hashPassword(userToCreate.Password)
-	if err != nil {
-		return nil, err
-	}
+	err = s.unitOfWork.Do(ctx, func(tx ports.Transaction) error {
+		err := checkIfUserAlreadyExists(ctx, tx.Users(), userToCreate)
+		if err != nil {
+			return err
+		}
 
-	err = s.userRepository.Create(userToCreate)
+		// A retried attempt reuses the hash computed by the previous one.
+		if !userToCreate.Password.IsHashed {
+			err = s.hashPassword(ctx, userToCreate.Password)
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Users().Create(ctx, userToCreate)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return NewSignupResponse(userToCreate), nil
+	s.logger.InfoContext(ctx, "user created",
+		slog.Uint64("user_id", uint64(userToCreate.ID)),
+		slog.String("role", string(userToCreate.Role)),
+	)
+	return userToCreate, nil
 }
 
-func (s *Users) checkIfUserAlreadyExists(user *domain.User) error {
-	foundUser, err := s.userRepository.FindByEmail(user.Email.Value)
+func checkIfUserAlreadyExists(ctx context.Context, users ports.UsersRepository, user *domain.User) error {
+	foundUser, err := users.FindByEmail(ctx, user.Email.Value)
 	if err != nil {
 		return err
 	}
@@ -80,7 +179,7 @@ func (s *Users) checkIfUserAlreadyExists(user *domain.User) error {
 		return ErrEmailAlreadyTaken
 	}
 
-	foundUser, err = s.userRepository.FindByUsername(user.Username)
+	foundUser, err = users.FindByUsername(ctx, user.Username)
 	if err != nil {
 		return err
 	}
@@ -91,10 +190,15 @@ func (s *Users) checkIfUserAlreadyExists(user *domain.User) error {
 	return nil
 }
 
-func (s *Users) hashPassword(password *domain.Password) error {
+// hashPassword and comparePassword trace the Hasher calls, since hashing
+// dominates the duration of signups and logins.
+func (s *Users) hashPassword(ctx context.Context, password *domain.Password) (err error) {
 	if password.IsHashed {
 		return ErrPasswordAlreadyHashed
 	}
+
+	_, span := s.tracer.Start(ctx, "Hasher.Hash")
+	defer func() { span.End(err) }()
 	hashedPassword, err := s.hasher.Hash(password.Value)
 	if err != nil {
 		return err
@@ -104,6 +208,258 @@ func (s *Users) hashPassword(password *domain.Password) error {
 	return nil
 }
 
+func (s *Users) comparePassword(ctx context.Context, givenPassword, hashedPassword string) bool {
+	_, span := s.tracer.Start(ctx, "Hasher.Compare")
+	defer span.End(nil)
+	return s.hasher.Compare(givenPassword, hashedPassword)
+}
+
+// Me returns the profile of the principal attached to ctx.
+func (s *Users) Me(ctx context.Context) (profile *domain.UserProfile, err error) {
+	ctx, span := s.tracer.Start(ctx, "Users.Me")
+	defer func() { span.End(err) }()
+
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	user, err := currentUser(ctx, s.userRepository, principal)
+	if err != nil {
+		return nil, err
+	}
+	return NewUserProfile(user), nil
+}
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// List pages through every user and is restricted to admins. query.First is
+// clamped to MaxPageSize and defaults to DefaultPageSize.
+func (s *Users) List(ctx context.Context, query domain.ListUsersQuery) (page *domain.UsersPage, err error) {
+	ctx, span := s.tracer.Start(ctx, "Users.List")
+	defer func() { span.End(err) }()
+
+	if err := requireAdmin(ctx, s.userRepository); err != nil {
+		return nil, err
+	}
+
+	first := query.First
+	if first <= 0 {
+		first = DefaultPageSize
+	}
+	first = min(first, MaxPageSize)
+
+	// Fetching one extra user tells whether another page follows.
+	users, err := s.userRepository.List(ctx, query.After, first+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page = &domain.UsersPage{HasNextPage: len(users) > first}
+	for _, user := range users[:min(len(users), first)] {
+		page.Users = append(page.Users, NewUserProfile(user))
+	}
+	return page, nil
+}
+
+func NewUserProfile(user *domain.User) *domain.UserProfile {
+	return &domain.UserProfile{
+		ID:       user.ID,
+		Username: user.Username,
+		Email:    user.Email.Value,
+		Role:     user.Role,
+		Disabled: user.Disabled,
+	}
+}
+
+// requireAdmin checks that the principal attached to ctx is an admin.
+// Principals standing for a user are checked against the stored user too:
+// their token outlives a change of role or the user being disabled.
+func requireAdmin(ctx context.Context, users ports.UsersRepository) error {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	if !principal.IsAdmin() {
+		return ErrForbidden
+	}
+	if principal.UserID == 0 {
+		return nil
+	}
+
+	user, err := currentUser(ctx, users, principal)
+	if err != nil {
+		return err
+	}
+	if user.Role != domain.RoleAdmin {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// currentUser returns the user principal stands for, rejecting users that
+// were deleted or disabled since they authenticated.
+func currentUser(ctx context.Context, users ports.UsersRepository, principal *domain.Principal) (*domain.User, error) {
+	user, err := users.FindByID(ctx, principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+	if user.Disabled {
+		return nil, ErrAccountDisabled
+	}
+	return user, nil
+}
+
+// CreateUser creates a user with the given role, applying the same rules as
+// Signup.
+func (s *Users) CreateUser(ctx context.Context, payload *domain.SignupPayload, role domain.Role) (profile *domain.UserProfile, err error) {
+	ctx, span := s.tracer.Start(ctx, "Users.CreateUser")
+	defer func() { span.End(err) }()
+
+	if err := requireAdmin(ctx, s.userRepository); err != nil {
+		return nil, err
+	}
+	if err := role.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidUserPayload, err)
+	}
+
+	user, err := s.create(ctx, payload, role)
+	if err != nil {
+		return nil, err
+	}
+	return NewUserProfile(user), nil
+}
+
+// GetUser looks a user up by identifier: an all-digit identifier is an ID,
+// one containing "@" an email, anything else a username.
+func (s *Users) GetUser(ctx context.Context, identifier string) (profile *domain.UserProfile, err error) {
+	ctx, span := s.tracer.Start(ctx, "Users.GetUser")
+	defer func() { span.End(err) }()
+
+	if err := requireAdmin(ctx, s.userRepository); err != nil {
+		return nil, err
+	}
+
+	user, err := findUser(ctx, s.userRepository, identifier)
+	if err != nil {
+		return nil, err
+	}
+	return NewUserProfile(user), nil
+}
+
+// DisableUser prevents the user from logging in. Disabling is idempotent.
+func (s *Users) DisableUser(ctx context.Context, identifier string) (profile *domain.UserProfile, err error) {
+	ctx, span := s.tracer.Start(ctx, "Users.DisableUser")
+	defer func() { span.End(err) }()
+
+	if err := requireAdmin(ctx, s.userRepository); err != nil {
+		return nil, err
+	}
+	return s.updateUser(ctx, "disable", identifier, func(user *domain.User) {
+		user.Disabled = true
+	})
+}
+
+// ResetPassword validates and hashes password before replacing the user's.
+func (s *Users) ResetPassword(ctx context.Context, identifier, password string) (profile *domain.UserProfile, err error) {
+	ctx, span := s.tracer.Start(ctx, "Users.ResetPassword")
+	defer func() { span.End(err) }()
+
+	if err := requireAdmin(ctx, s.userRepository); err != nil {
+		return nil, err
+	}
+
+	newPassword := &domain.Password{Value: password}
+	if err := newPassword.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidUserPayload, err)
+	}
+	if err := s.hashPassword(ctx, newPassword); err != nil {
+		return nil, err
+	}
+
+	return s.updateUser(ctx, "reset_password", identifier, func(user *domain.User) {
+		user.Password = newPassword
+	})
+}
+
+func (s *Users) SetRole(ctx context.Context, identifier string, role domain.Role) (profile *domain.UserProfile, err error) {
+	ctx, span := s.tracer.Start(ctx, "Users.SetRole")
+	defer func() { span.End(err) }()
+
+	if err := requireAdmin(ctx, s.userRepository); err != nil {
+		return nil, err
+	}
+
+	if err := role.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidUserPayload, err)
+	}
+	return s.updateUser(ctx, "set_role", identifier, func(user *domain.User) {
+		user.Role = role
+	})
+}
+
+// updateUser applies update to the user in a transaction and logs action
+// once it is committed. Callers check the caller is an admin first.
+func (s *Users) updateUser(ctx context.Context, action, identifier string, update func(user *domain.User)) (*domain.UserProfile, error) {
+	var updated *domain.User
+	err := s.unitOfWork.Do(ctx, func(tx ports.Transaction) error {
+		user, err := findUser(ctx, tx.Users(), identifier)
+		if err != nil {
+			return err
+		}
+		update(user)
+		updated = user
+		return tx.Users().Update(ctx, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "user updated",
+		slog.String("action", action),
+		slog.Uint64("user_id", uint64(updated.ID)),
+	)
+	return NewUserProfile(updated), nil
+}
+
+func findUser(ctx context.Context, users ports.UsersRepository, identifier string) (*domain.User, error) {
+	var user *domain.User
+	var err error
+
+	if id, parseErr := strconv.ParseUint(identifier, 10, 0); parseErr == nil {
+		user, err = users.FindByID(ctx, uint(id))
+	} else if strings.Contains(identifier, "@") {
+		email, normalizeErr := (&domain.Email{Value: identifier}).Normalized()
+		if normalizeErr != nil {
+			return nil, ErrUserNotFound
+		}
+		user, err = users.FindByEmail(ctx, email)
+	} else {
+		user, err = users.FindByUsername(ctx, identifier)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+var (
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrForbidden       = errors.New("forbidden")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrAccountDisabled = errors.New("account disabled")
+)
+
 var (
 	ErrEmailAlreadyTaken     = errors.New("email already taken")
 	ErrUsernameAlreadyTaken  = errors.New("username already taken")
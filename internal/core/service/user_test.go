@@ -1,22 +1,42 @@
 package service_test
 
 import (
+	"context"
+	"strings"
 	"testing"
 
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/metrics"
+	memoryRepository "github.com/raphael-foliveira/go-table-tests/internal/adapters/repository/memory"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/tracing"
 	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/ports"
 	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
 	"github.com/raphael-foliveira/go-table-tests/pkg/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func setUp(t *testing.T) (*mocks.MockHasher, *mocks.MockUsersRepository, *service.Users) {
 	hasherMock := mocks.NewMockHasher(t)
 	userRepositoryMock := mocks.NewMockUsersRepository(t)
-	userService := service.NewUsersService(userRepositoryMock, hasherMock)
+	userService := service.NewUsersService(userRepositoryMock, hasherMock, passThroughUnitOfWork(t, userRepositoryMock), logging.Discard(), metrics.Discard(), tracing.Discard())
 	return hasherMock, userRepositoryMock, userService
 }
 
+// passThroughUnitOfWork runs every unit of work directly against users.
+func passThroughUnitOfWork(t *testing.T, users ports.UsersRepository) *mocks.MockUnitOfWork {
+	transactionMock := mocks.NewMockTransaction(t)
+	transactionMock.EXPECT().Users().Return(users).Maybe()
+	unitOfWorkMock := mocks.NewMockUnitOfWork(t)
+	unitOfWorkMock.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, fn func(tx ports.Transaction) error) error {
+			return fn(transactionMock)
+		}).Maybe()
+	return unitOfWorkMock
+}
+
 func TestUserServiceLogin_Success(t *testing.T) {
 	tests := []struct {
 		expectedData          *domain.LoginResponse
@@ -32,6 +52,8 @@ func TestUserServiceLogin_Success(t *testing.T) {
 			expectedData: &domain.LoginResponse{
 				Username: "testuser",
 				Email:    "test@user.com",
+				Role:     domain.RoleUser,
+				UserID:   7,
 			},
 			mockFindByEmailResult: &domain.User{
 				Email: &domain.Email{
@@ -42,6 +64,8 @@ func TestUserServiceLogin_Success(t *testing.T) {
 					Value:    "hashedPassword",
 				},
 				Username: "testuser",
+				Role:     domain.RoleUser,
+				ID:       7,
 			},
 		},
 	}
@@ -51,18 +75,17 @@ func TestUserServiceLogin_Success(t *testing.T) {
 			hasherMock, userRepositoryMock, userService := setUp(t)
 
 			userRepositoryMock.EXPECT().
-				FindByEmail(tt.userEmail).
+				FindByEmail(mock.Anything, tt.userEmail).
 				Return(tt.mockFindByEmailResult, nil)
 
 			hasherMock.EXPECT().
 				Compare(tt.userPassword, tt.mockFindByEmailResult.Password.Value).
 				Return(true)
 
-			result, err := userService.Login(tt.userEmail, tt.userPassword)
+			result, err := userService.Login(context.Background(), tt.userEmail, tt.userPassword)
 			assert.NoError(t, err)
 
-			assert.Equal(t, tt.expectedData.Email, result.Email)
-			assert.Equal(t, tt.expectedData.Username, result.Username)
+			assert.Equal(t, tt.expectedData, result)
 		})
 	}
 }
@@ -101,14 +124,14 @@ func TestUserServiceLogin_InvalidPassword(t *testing.T) {
 			hasherMock, userRepositoryMock, userService := setUp(t)
 
 			userRepositoryMock.EXPECT().
-				FindByEmail(tt.userEmail).
+				FindByEmail(mock.Anything, tt.userEmail).
 				Return(tt.mockFindByEmailResult, nil)
 
 			hasherMock.EXPECT().
 				Compare(mock.Anything, mock.Anything).
 				Return(tt.hasherMockResult)
 
-			_, err := userService.Login(tt.userEmail, "invalidPassword")
+			_, err := userService.Login(context.Background(), tt.userEmail, "invalidPassword")
 			assert.ErrorIs(t, service.ErrInvalidCredentials, err)
 		})
 	}
@@ -132,10 +155,10 @@ func TestUserServiceLogin_InvalidEmail(t *testing.T) {
 			_, userRepositoryMock, userService := setUp(t)
 
 			userRepositoryMock.EXPECT().
-				FindByEmail(tt.userEmail).
+				FindByEmail(mock.Anything, tt.userEmail).
 				Return(nil, nil)
 
-			_, err := userService.Login(tt.userEmail, tt.userPassword)
+			_, err := userService.Login(context.Background(), tt.userEmail, tt.userPassword)
 			assert.ErrorIs(t, service.ErrInvalidCredentials, err)
 		})
 	}
@@ -155,6 +178,12 @@ func TestUserServiceSignup_InvalidPayload(t *testing.T) {
 			payloadPassword: "invp",
 			expectedError:   domain.ErrPasswordTooShort,
 		},
+		{
+			name:            "password over 72 bytes",
+			payloadEmail:    "valid@email.com",
+			payloadPassword: strings.Repeat("é", 40),
+			expectedError:   domain.ErrPasswordTooLong,
+		},
 		{
 			name:            "invalid email",
 			payloadEmail:    "invalid_email.com",
@@ -167,7 +196,7 @@ func TestUserServiceSignup_InvalidPayload(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			_, _, userService := setUp(t)
 
-			response, err := userService.Signup(&domain.SignupPayload{
+			response, err := userService.Signup(context.Background(), &domain.SignupPayload{
 				Username: tt.payloadUsername,
 				Email:    tt.payloadEmail,
 				Password: tt.payloadPassword,
@@ -199,18 +228,18 @@ func TestUserServiceSignup_Success(t *testing.T) {
 			hasherMock, userRepositoryMock, userService := setUp(t)
 
 			userRepositoryMock.EXPECT().
-				FindByEmail(tt.payloadEmail).
+				FindByEmail(mock.Anything, tt.payloadEmail).
 				Return(nil, nil)
 
 			userRepositoryMock.EXPECT().
-				FindByUsername(tt.payloadUsername).
+				FindByUsername(mock.Anything, tt.payloadUsername).
 				Return(nil, nil)
 
 			hasherMock.EXPECT().Hash(tt.payloadPassword).Return("hashedPassword", nil)
 			userRepositoryMock.EXPECT().
-				Create(mock.Anything).Return(nil)
+				Create(mock.Anything, mock.Anything).Return(nil)
 
-			response, err := userService.Signup(&domain.SignupPayload{
+			response, err := userService.Signup(context.Background(), &domain.SignupPayload{
 				Username: tt.payloadUsername,
 				Email:    tt.payloadEmail,
 				Password: tt.payloadPassword,
@@ -256,16 +285,16 @@ func TestUserServiceSignup_Conflict(t *testing.T) {
 			_, userRepositoryMock, userService := setUp(t)
 
 			userRepositoryMock.EXPECT().
-				FindByEmail(tt.payloadEmail).
+				FindByEmail(mock.Anything, tt.payloadEmail).
 				Return(tt.findByEmailReturn, nil)
 
 			if tt.findByEmailReturn == nil {
 				userRepositoryMock.EXPECT().
-					FindByUsername(tt.payloadUsername).
+					FindByUsername(mock.Anything, tt.payloadUsername).
 					Return(tt.findByUsernameReturn, nil)
 			}
 
-			response, err := userService.Signup(&domain.SignupPayload{
+			response, err := userService.Signup(context.Background(), &domain.SignupPayload{
 				Username: tt.payloadUsername,
 				Email:    tt.payloadEmail,
 				Password: tt.payloadPassword,
@@ -277,3 +306,590 @@ func TestUserServiceSignup_Conflict(t *testing.T) {
 		})
 	}
 }
+
+func TestUserServiceSignup_NormalizesEmail(t *testing.T) {
+	tests := []struct {
+		name            string
+		payloadEmail    string
+		normalizedEmail string
+	}{
+		{
+			name:            "Case and whitespace",
+			payloadEmail:    "  Test@Test.COM ",
+			normalizedEmail: "test@test.com",
+		},
+		{
+			name:            "Internationalized domain",
+			payloadEmail:    "test@Bücher.example",
+			normalizedEmail: "test@xn--bcher-kva.example",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasherMock, userRepositoryMock, userService := setUp(t)
+
+			userRepositoryMock.EXPECT().
+				FindByEmail(mock.Anything, tt.normalizedEmail).
+				Return(nil, nil)
+
+			userRepositoryMock.EXPECT().
+				FindByUsername(mock.Anything, "testusername").
+				Return(nil, nil)
+
+			hasherMock.EXPECT().Hash(mock.Anything).Return("hashedPassword", nil)
+			userRepositoryMock.EXPECT().
+				Create(mock.Anything, mock.MatchedBy(func(user *domain.User) bool {
+					return user.Email.Value == tt.normalizedEmail
+				})).Return(nil)
+
+			response, err := userService.Signup(context.Background(), &domain.SignupPayload{
+				Username: "testusername",
+				Email:    tt.payloadEmail,
+				Password: "valid_password",
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.normalizedEmail, response.Email)
+		})
+	}
+}
+
+func TestUserServiceLogin_NormalizesEmail(t *testing.T) {
+	hasherMock, userRepositoryMock, userService := setUp(t)
+
+	userRepositoryMock.EXPECT().
+		FindByEmail(mock.Anything, "test@user.com").
+		Return(&domain.User{
+			Email:    &domain.Email{Value: "test@user.com"},
+			Password: &domain.Password{Value: "hashedPassword", IsHashed: true},
+			Username: "testuser",
+		}, nil)
+	hasherMock.EXPECT().Compare("unhashedPassword", "hashedPassword").Return(true)
+
+	response, err := userService.Login(context.Background(), " Test@User.com", "unhashedPassword")
+	assert.NoError(t, err)
+	assert.Equal(t, "test@user.com", response.Email)
+}
+
+func TestUserService_DomainBlockedAfterSignup(t *testing.T) {
+	userService, admin := setUpAdmin(t)
+	domain.SetEmailPolicy(domain.EmailPolicy{DisposableDomains: []string{"user.com"}})
+	t.Cleanup(func() { domain.SetEmailPolicy(domain.EmailPolicy{}) })
+
+	response, err := userService.Login(context.Background(), "test@user.com", "valid_password")
+	require.NoError(t, err)
+	assert.Equal(t, "test@user.com", response.Email)
+
+	profile, err := userService.GetUser(admin, "test@user.com")
+	require.NoError(t, err)
+	assert.Equal(t, "testuser", profile.Username)
+
+	_, err = userService.Signup(context.Background(), &domain.SignupPayload{
+		Email:    "other@user.com",
+		Username: "otheruser",
+		Password: "valid_password",
+	})
+	assert.ErrorIs(t, err, domain.ErrEmailDisposable)
+}
+
+func TestUserServiceMe(t *testing.T) {
+	tests := []struct {
+		ctx           context.Context
+		foundUser     *domain.User
+		expectedError error
+		name          string
+		lookup        bool
+	}{
+		{
+			name: "Authenticated",
+			ctx:  domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: 1, Role: domain.RoleUser}),
+			foundUser: &domain.User{
+				ID:       1,
+				Username: "testuser",
+				Email:    &domain.Email{Value: "test@user.com"},
+				Role:     domain.RoleUser,
+			},
+			lookup: true,
+		},
+		{
+			name:          "No principal",
+			ctx:           context.Background(),
+			expectedError: service.ErrUnauthenticated,
+		},
+		{
+			name:          "Deleted user",
+			ctx:           domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: 1, Role: domain.RoleUser}),
+			lookup:        true,
+			expectedError: service.ErrUnauthenticated,
+		},
+		{
+			name: "Disabled user",
+			ctx:  domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: 1, Role: domain.RoleUser}),
+			foundUser: &domain.User{
+				ID:       1,
+				Username: "testuser",
+				Email:    &domain.Email{Value: "test@user.com"},
+				Role:     domain.RoleUser,
+				Disabled: true,
+			},
+			lookup:        true,
+			expectedError: service.ErrAccountDisabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, userRepositoryMock, userService := setUp(t)
+
+			if tt.lookup {
+				userRepositoryMock.EXPECT().FindByID(mock.Anything, uint(1)).Return(tt.foundUser, nil)
+			}
+
+			profile, err := userService.Me(tt.ctx)
+			assert.ErrorIs(t, err, tt.expectedError)
+			if tt.expectedError == nil {
+				assert.Equal(t, &domain.UserProfile{
+					ID:       1,
+					Username: "testuser",
+					Email:    "test@user.com",
+					Role:     domain.RoleUser,
+				}, profile)
+			}
+		})
+	}
+}
+
+func TestUserServiceList_Authorization(t *testing.T) {
+	tests := []struct {
+		ctx           context.Context
+		expectedError error
+		name          string
+	}{
+		{
+			name:          "No principal",
+			ctx:           context.Background(),
+			expectedError: service.ErrUnauthenticated,
+		},
+		{
+			name:          "Not an admin",
+			ctx:           domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: 1, Role: domain.RoleUser}),
+			expectedError: service.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, userService := setUp(t)
+
+			_, err := userService.List(tt.ctx, domain.ListUsersQuery{})
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestUserServiceList_Pagination(t *testing.T) {
+	admin := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: 1, Role: domain.RoleAdmin})
+
+	tests := []struct {
+		name                string
+		query               domain.ListUsersQuery
+		found               int
+		expectedLimit       int
+		expectedUsers       int
+		expectedHasNextPage bool
+	}{
+		{
+			name:                "Default page size",
+			query:               domain.ListUsersQuery{},
+			found:               service.DefaultPageSize + 1,
+			expectedLimit:       service.DefaultPageSize + 1,
+			expectedUsers:       service.DefaultPageSize,
+			expectedHasNextPage: true,
+		},
+		{
+			name:          "Last page",
+			query:         domain.ListUsersQuery{First: 5, After: 10},
+			found:         3,
+			expectedLimit: 6,
+			expectedUsers: 3,
+		},
+		{
+			name:          "Clamped page size",
+			query:         domain.ListUsersQuery{First: 1000},
+			found:         0,
+			expectedLimit: service.MaxPageSize + 1,
+			expectedUsers: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, userRepositoryMock, userService := setUp(t)
+
+			var found []*domain.User
+			for i := 0; i < tt.found; i++ {
+				found = append(found, &domain.User{
+					ID:    tt.query.After + uint(i) + 1,
+					Email: &domain.Email{Value: "test@user.com"},
+				})
+			}
+			userRepositoryMock.EXPECT().
+				FindByID(mock.Anything, uint(1)).
+				Return(&domain.User{ID: 1, Email: &domain.Email{Value: "admin@user.com"}, Role: domain.RoleAdmin}, nil)
+			userRepositoryMock.EXPECT().List(mock.Anything, tt.query.After, tt.expectedLimit).Return(found, nil)
+
+			page, err := userService.List(admin, tt.query)
+			assert.NoError(t, err)
+			assert.Len(t, page.Users, tt.expectedUsers)
+			assert.Equal(t, tt.expectedHasNextPage, page.HasNextPage)
+		})
+	}
+}
+
+func setUpAdmin(t *testing.T) (*service.Users, context.Context) {
+	hasherMock := mocks.NewMockHasher(t)
+	hasherMock.EXPECT().Hash(mock.Anything).RunAndReturn(func(password string) (string, error) {
+		return "hashed:" + password, nil
+	}).Maybe()
+	hasherMock.EXPECT().Compare(mock.Anything, mock.Anything).RunAndReturn(func(given, hashed string) bool {
+		return hashed == "hashed:"+given
+	}).Maybe()
+
+	users := memoryRepository.NewUsers()
+	userService := service.NewUsersService(users, hasherMock, memoryRepository.NewUnitOfWork(users), logging.Discard(), metrics.Discard(), tracing.Discard())
+	admin := domain.ContextWithPrincipal(context.Background(), &domain.Principal{Role: domain.RoleAdmin})
+
+	_, err := userService.CreateUser(admin, &domain.SignupPayload{
+		Email:    "test@user.com",
+		Username: "testuser",
+		Password: "valid_password",
+	}, domain.RoleUser)
+	require.NoError(t, err)
+
+	return userService, admin
+}
+
+func TestUserServiceAdmin_Authorization(t *testing.T) {
+	userService, _ := setUpAdmin(t)
+	ctx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: 1, Role: domain.RoleUser})
+
+	tests := []struct {
+		call func() error
+		name string
+	}{
+		{
+			name: "CreateUser",
+			call: func() error {
+				_, err := userService.CreateUser(ctx, &domain.SignupPayload{}, domain.RoleAdmin)
+				return err
+			},
+		},
+		{
+			name: "GetUser",
+			call: func() error {
+				_, err := userService.GetUser(ctx, "1")
+				return err
+			},
+		},
+		{
+			name: "DisableUser",
+			call: func() error {
+				_, err := userService.DisableUser(ctx, "1")
+				return err
+			},
+		},
+		{
+			name: "ResetPassword",
+			call: func() error {
+				_, err := userService.ResetPassword(ctx, "1", "new_password")
+				return err
+			},
+		},
+		{
+			name: "SetRole",
+			call: func() error {
+				_, err := userService.SetRole(ctx, "1", domain.Role("owner"))
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.call(), service.ErrForbidden)
+		})
+	}
+}
+
+func TestUserServiceAdmin_GetUser(t *testing.T) {
+	userService, admin := setUpAdmin(t)
+
+	tests := []struct {
+		expectedError error
+		name          string
+		identifier    string
+	}{
+		{name: "By ID", identifier: "1"},
+		{name: "By email", identifier: " Test@User.com"},
+		{name: "By username", identifier: "TestUser"},
+		{name: "Unknown ID", identifier: "2", expectedError: service.ErrUserNotFound},
+		{name: "Unknown username", identifier: "missing", expectedError: service.ErrUserNotFound},
+		{name: "Malformed email", identifier: "@", expectedError: service.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := userService.GetUser(admin, tt.identifier)
+			assert.ErrorIs(t, err, tt.expectedError)
+			if tt.expectedError == nil {
+				assert.Equal(t, uint(1), profile.ID)
+			}
+		})
+	}
+}
+
+func TestUserServiceAdmin_DisableUser(t *testing.T) {
+	userService, admin := setUpAdmin(t)
+
+	profile, err := userService.DisableUser(admin, "testuser")
+	require.NoError(t, err)
+	assert.True(t, profile.Disabled)
+
+	_, err = userService.Login(context.Background(), "test@user.com", "valid_password")
+	assert.ErrorIs(t, err, service.ErrAccountDisabled)
+
+	_, err = userService.Login(context.Background(), "test@user.com", "wrong_password")
+	assert.ErrorIs(t, err, service.ErrInvalidCredentials)
+}
+
+func TestUserServiceAdmin_ResetPassword(t *testing.T) {
+	userService, admin := setUpAdmin(t)
+
+	_, err := userService.ResetPassword(admin, "testuser", "short")
+	assert.ErrorIs(t, err, domain.ErrPasswordTooShort)
+
+	_, err = userService.ResetPassword(admin, "testuser", "new_password")
+	require.NoError(t, err)
+
+	_, err = userService.Login(context.Background(), "test@user.com", "valid_password")
+	assert.ErrorIs(t, err, service.ErrInvalidCredentials)
+
+	_, err = userService.Login(context.Background(), "test@user.com", "new_password")
+	assert.NoError(t, err)
+}
+
+func TestUserServiceAdmin_SetRole(t *testing.T) {
+	userService, admin := setUpAdmin(t)
+
+	_, err := userService.SetRole(admin, "testuser", domain.Role("owner"))
+	assert.ErrorIs(t, err, domain.ErrRoleInvalid)
+
+	profile, err := userService.SetRole(admin, "testuser", domain.RoleAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleAdmin, profile.Role)
+
+	profile, err = userService.GetUser(admin, "testuser")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleAdmin, profile.Role)
+}
+
+func TestUserServiceAdmin_LooksUpAdminOnce(t *testing.T) {
+	tests := []struct {
+		call func(userService *service.Users, ctx context.Context) error
+		name string
+	}{
+		{
+			name: "DisableUser",
+			call: func(userService *service.Users, ctx context.Context) error {
+				_, err := userService.DisableUser(ctx, "testuser")
+				return err
+			},
+		},
+		{
+			name: "ResetPassword",
+			call: func(userService *service.Users, ctx context.Context) error {
+				_, err := userService.ResetPassword(ctx, "testuser", "new_password")
+				return err
+			},
+		},
+		{
+			name: "SetRole",
+			call: func(userService *service.Users, ctx context.Context) error {
+				_, err := userService.SetRole(ctx, "testuser", domain.RoleAdmin)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasherMock, userRepositoryMock, userService := setUp(t)
+			ctx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{UserID: 7, Role: domain.RoleAdmin})
+
+			hasherMock.EXPECT().Hash(mock.Anything).Return("hashed", nil).Maybe()
+			userRepositoryMock.EXPECT().
+				FindByID(mock.Anything, uint(7)).
+				Return(&domain.User{ID: 7, Role: domain.RoleAdmin}, nil).
+				Once()
+			userRepositoryMock.EXPECT().
+				FindByUsername(mock.Anything, "testuser").
+				Return(&domain.User{ID: 1, Username: "testuser", Email: &domain.Email{Value: "test@user.com"}, Role: domain.RoleUser}, nil)
+			userRepositoryMock.EXPECT().Update(mock.Anything, mock.Anything).Return(nil)
+
+			assert.NoError(t, tt.call(userService, ctx))
+		})
+	}
+}
+
+func TestUserServiceAdmin_CreateUser(t *testing.T) {
+	userService, admin := setUpAdmin(t)
+
+	_, err := userService.CreateUser(admin, &domain.SignupPayload{
+		Email:    "admin@user.com",
+		Username: "adminuser",
+		Password: "valid_password",
+	}, domain.Role("owner"))
+	assert.ErrorIs(t, err, domain.ErrRoleInvalid)
+
+	profile, err := userService.CreateUser(admin, &domain.SignupPayload{
+		Email:    "admin@user.com",
+		Username: "adminuser",
+		Password: "valid_password",
+	}, domain.RoleAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleAdmin, profile.Role)
+
+	_, err = userService.CreateUser(admin, &domain.SignupPayload{
+		Email:    "admin@user.com",
+		Username: "otheradmin",
+		Password: "valid_password",
+	}, domain.RoleAdmin)
+	assert.ErrorIs(t, err, service.ErrEmailAlreadyTaken)
+}
+
+func TestUserService_Metrics(t *testing.T) {
+	tests := []struct {
+		call           func(userService *service.Users) error
+		name           string
+		expectedLogin  string
+		expectedSignup string
+	}{
+		{
+			name: "Login success",
+			call: func(userService *service.Users) error {
+				_, err := userService.Login(context.Background(), "test@user.com", "valid_password")
+				return err
+			},
+			expectedLogin: service.OutcomeSuccess,
+		},
+		{
+			name: "Login with an invalid email",
+			call: func(userService *service.Users) error {
+				_, err := userService.Login(context.Background(), "invalid", "valid_password")
+				return err
+			},
+			expectedLogin: service.LoginOutcomeInvalidEmail,
+		},
+		{
+			name: "Login with an unknown email",
+			call: func(userService *service.Users) error {
+				_, err := userService.Login(context.Background(), "unknown@user.com", "valid_password")
+				return err
+			},
+			expectedLogin: service.LoginOutcomeUnknownEmail,
+		},
+		{
+			name: "Login with a wrong password",
+			call: func(userService *service.Users) error {
+				_, err := userService.Login(context.Background(), "test@user.com", "wrong_password")
+				return err
+			},
+			expectedLogin: service.LoginOutcomeWrongPassword,
+		},
+		{
+			name: "Signup success",
+			call: func(userService *service.Users) error {
+				_, err := userService.Signup(context.Background(), &domain.SignupPayload{
+					Email:    "new@user.com",
+					Username: "newuser",
+					Password: "valid_password",
+				})
+				return err
+			},
+			expectedSignup: service.OutcomeSuccess,
+		},
+		{
+			name: "Signup with a taken email",
+			call: func(userService *service.Users) error {
+				_, err := userService.Signup(context.Background(), &domain.SignupPayload{
+					Email:    "test@user.com",
+					Username: "newuser",
+					Password: "valid_password",
+				})
+				return err
+			},
+			expectedSignup: service.SignupOutcomeEmailTaken,
+		},
+		{
+			name: "Signup with an invalid payload",
+			call: func(userService *service.Users) error {
+				_, err := userService.Signup(context.Background(), &domain.SignupPayload{
+					Email:    "new@user.com",
+					Username: "newuser",
+					Password: "short",
+				})
+				return err
+			},
+			expectedSignup: service.SignupOutcomeInvalidPayload,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasherMock := mocks.NewMockHasher(t)
+			hasherMock.EXPECT().Hash(mock.Anything).RunAndReturn(func(password string) (string, error) {
+				return "hashed:" + password, nil
+			}).Maybe()
+			hasherMock.EXPECT().Compare(mock.Anything, mock.Anything).RunAndReturn(func(given, hashed string) bool {
+				return hashed == "hashed:"+given
+			}).Maybe()
+
+			users := memoryRepository.NewUsers()
+			require.NoError(t, users.Create(context.Background(), &domain.User{
+				Username: "testuser",
+				Email:    &domain.Email{Value: "test@user.com"},
+				Password: &domain.Password{Value: "hashed:valid_password", IsHashed: true},
+				Role:     domain.RoleUser,
+			}))
+
+			metricsMock := mocks.NewMockMetrics(t)
+			if tt.expectedLogin != "" {
+				metricsMock.EXPECT().RecordLogin(tt.expectedLogin).Once()
+			}
+			if tt.expectedSignup != "" {
+				metricsMock.EXPECT().RecordSignup(tt.expectedSignup).Once()
+			}
+			userService := service.NewUsersService(users, hasherMock, memoryRepository.NewUnitOfWork(users), logging.Discard(), metricsMock, tracing.Discard())
+
+			tt.call(userService)
+		})
+	}
+}
+
+func TestTimedHasher(t *testing.T) {
+	hasherMock := mocks.NewMockHasher(t)
+	hasherMock.EXPECT().Hash("valid_password").Return("hashed", nil)
+	hasherMock.EXPECT().Compare("valid_password", "hashed").Return(true)
+
+	metricsMock := mocks.NewMockMetrics(t)
+	metricsMock.EXPECT().ObserveHashing(service.HashOperationHash, mock.Anything).Once()
+	metricsMock.EXPECT().ObserveHashing(service.HashOperationCompare, mock.Anything).Once()
+
+	hasher := service.NewTimedHasher(hasherMock, metricsMock)
+
+	hashed, err := hasher.Hash("valid_password")
+	require.NoError(t, err)
+	assert.Equal(t, "hashed", hashed)
+	assert.True(t, hasher.Compare("valid_password", "hashed"))
+}
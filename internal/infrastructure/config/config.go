@@ -2,43 +2,248 @@ package config
 
 import (
 	"errors"
-	"fmt"
-	"os"
+	"io/fs"
+	"log/slog"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/metrics"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/tracing"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/lifecycle"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/server"
+	"golang.org/x/crypto/bcrypt"
 )
 
+// Config is the application configuration. Every field is addressed by its
+// dotted key, such as "server.port", in files and flags, and by the upper
+// snake case of that key, such as SERVER_PORT, in the environment unless an
+// env tag names another variable. Each variable can instead be read from the
+// file named by the same variable suffixed with _FILE, and any value can be
+// a secret:// reference into the encrypted secrets store. Fields tagged
+// reload can change while the application runs; see Reloader.
 type Config struct {
-	DatabaseURL string
+	Server   ServerConfig   `config:"server"`
+	TLS      TLSConfig      `config:"tls"`
+	Database DatabaseConfig `config:"database"`
+	Auth     AuthConfig     `config:"auth"`
+	Hashing  HashingConfig  `config:"hashing"`
+	Log      LogConfig      `config:"log"`
+	Trace    TraceConfig    `config:"trace"`
+	Email    EmailConfig    `config:"email"`
+	GRPC     GRPCConfig     `config:"grpc"`
+	Metrics  MetricsConfig  `config:"metrics"`
+
+	// file is the configuration file Load read, if any.
+	file string
 }
 
-func NewConfig(filePath string) (*Config, error) {
-	err := godotenv.Load(filePath)
-	if err != nil {
-		return nil, err
-	}
+type ServerConfig struct {
+	Host              string        `config:"host"`
+	Port              int           `config:"port"`
+	ReadHeaderTimeout time.Duration `config:"read_header_timeout"`
+	ReadTimeout       time.Duration `config:"read_timeout"`
+	WriteTimeout      time.Duration `config:"write_timeout"`
+	IdleTimeout       time.Duration `config:"idle_timeout"`
+	ShutdownTimeout   time.Duration `config:"shutdown_timeout"`
+	// DrainDelay is how long the server keeps serving after /readyz turns
+	// unavailable on shutdown. It is part of ShutdownTimeout.
+	DrainDelay time.Duration `config:"drain_delay"`
+	// ReadinessTimeout bounds the dependency checks behind /readyz.
+	ReadinessTimeout time.Duration `config:"readiness_timeout"`
+	// BodyLimit is the maximum request body size in bytes.
+	BodyLimit int `config:"body_limit"`
+	// CORSAllowedOrigins are the exact origins, such as
+	// https://app.example.com, allowed to call the API from a browser.
+	CORSAllowedOrigins    []string      `config:"cors_allowed_origins"`
+	CORSAllowCredentials  bool          `config:"cors_allow_credentials"`
+	HSTSMaxAge            time.Duration `config:"hsts_max_age"`
+	ContentSecurityPolicy string        `config:"content_security_policy"`
+}
 
-	err = validateConfig()
-	if err != nil {
-		return nil, err
-	}
+// TLSConfig enables HTTPS when CertFile is set, and optional mutual TLS
+// when ClientCAFile is set too: clients that present a certificate must
+// have it signed by ClientCAFile, others connect without one. The
+// certificate is reloaded when its files change.
+type TLSConfig struct {
+	CertFile string `config:"cert_file"`
+	KeyFile  string `config:"key_file"`
+	// MinVersion is "1.2" or "1.3".
+	MinVersion string `config:"min_version"`
+	// CipherSuites are crypto/tls suite names, applied to TLS 1.2 only. The
+	// Go defaults apply when empty.
+	CipherSuites []string `config:"cipher_suites"`
+	ClientCAFile string   `config:"client_ca_file"`
+	// AdminClients are the client certificate identities granted the admin
+	// role.
+	AdminClients []string `config:"admin_clients"`
+}
+
+type DatabaseConfig struct {
+	URL             Secret        `config:"url"`
+	MaxOpenConns    int           `config:"max_open_conns"`
+	MaxIdleConns    int           `config:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `config:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `config:"conn_max_idle_time"`
+}
+
+// AuthConfig configures the access tokens and the cookies of cookie
+// sessions. Without SigningKey a random key is generated at startup, so
+// tokens do not survive a restart.
+type AuthConfig struct {
+	SigningKey     Secret        `config:"signing_key"`
+	AccessTokenTTL time.Duration `config:"access_token_ttl" reload:"true"`
+	CookieDomain   string        `config:"cookie_domain"`
+	CookiePath     string        `config:"cookie_path"`
+	CookieSecure   bool          `config:"cookie_secure"`
+	// CookieSameSite is "strict", "lax" or "none"; "none" requires
+	// CookieSecure.
+	CookieSameSite string `config:"cookie_same_site"`
+}
+
+type HashingConfig struct {
+	BcryptCost int `config:"bcrypt_cost"`
+}
+
+type LogConfig struct {
+	// Format is logging.FormatJSON or logging.FormatText.
+	Format string     `config:"format"`
+	Level  slog.Level `config:"level" reload:"true"`
+}
+
+type TraceConfig struct {
+	// Exporter is tracing.ExporterNone, ExporterOTLP or ExporterStdout.
+	Exporter string `config:"exporter"`
+}
 
+type EmailConfig struct {
+	DisposableDomains []string `config:"disposable_domains" env:"DISPOSABLE_EMAIL_DOMAINS" reload:"true"`
+	// CanonicalizeAliases changes the stored form of emails, which Login
+	// looks users up by, so it only applies on restart. Changing it on a
+	// populated database needs a data migration re-normalizing every email;
+	// otherwise the accounts created under the other setting cannot log in.
+	CanonicalizeAliases bool `config:"canonicalize_aliases" env:"CANONICALIZE_EMAIL_ALIASES"`
+}
+
+// GRPCConfig enables the gRPC transport when Port is non-zero. It listens on
+// server.host, requires at least one of APIKeys and is served with the TLS
+// configuration of the HTTP server, which must therefore be set.
+type GRPCConfig struct {
+	Port    int      `config:"port"`
+	APIKeys []Secret `config:"api_keys"`
+}
+
+// MetricsConfig serves the Prometheus metrics on a listener of their own,
+// apart from the public API, unless Address is empty. The default only
+// accepts local connections.
+type MetricsConfig struct {
+	Address string `config:"address"`
+}
+
+func Defaults() *Config {
+	security := server.DefaultSecurityOptions()
+	sessions := api.DefaultSessionOptions()
 	return &Config{
-		DatabaseURL: os.Getenv("DATABASE_URL"),
-	}, nil
+		Server: ServerConfig{
+			Port:                  3000,
+			ReadHeaderTimeout:     security.Timeouts.ReadHeader,
+			ReadTimeout:           security.Timeouts.Read,
+			WriteTimeout:          security.Timeouts.Write,
+			IdleTimeout:           security.Timeouts.Idle,
+			ShutdownTimeout:       lifecycle.DefaultShutdownTimeout,
+			DrainDelay:            lifecycle.DefaultDrainDelay,
+			ReadinessTimeout:      server.DefaultCheckTimeout,
+			BodyLimit:             int(security.BodyLimit),
+			HSTSMaxAge:            security.Headers.HSTSMaxAge,
+			ContentSecurityPolicy: security.Headers.ContentSecurityPolicy,
+		},
+		TLS: TLSConfig{
+			MinVersion: "1.2",
+		},
+		Database: DatabaseConfig{
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+		},
+		Auth: AuthConfig{
+			AccessTokenTTL: 15 * time.Minute,
+			CookiePath:     sessions.Path,
+			CookieSecure:   sessions.Secure,
+			CookieSameSite: "strict",
+		},
+		Hashing: HashingConfig{
+			BcryptCost: bcrypt.DefaultCost,
+		},
+		Log: LogConfig{
+			Format: logging.FormatJSON,
+			Level:  slog.LevelInfo,
+		},
+		Trace: TraceConfig{
+			Exporter: tracing.ExporterNone,
+		},
+		Metrics: MetricsConfig{
+			Address: metrics.DefaultAddress,
+		},
+	}
+}
+
+// File returns the configuration file the configuration was loaded from, or
+// an empty string.
+func (c *Config) File() string {
+	return c.file
+}
+
+// TLSOptions converts the validated TLS section for server.NewTLS.
+func (c *Config) TLSOptions() server.TLSOptions {
+	minVersion, _ := server.ParseTLSVersion(c.TLS.MinVersion)
+	cipherSuites, _ := server.ParseCipherSuites(c.TLS.CipherSuites)
+	return server.TLSOptions{
+		CertFile:     c.TLS.CertFile,
+		KeyFile:      c.TLS.KeyFile,
+		ClientCAFile: c.TLS.ClientCAFile,
+		MinVersion:   minVersion,
+		CipherSuites: cipherSuites,
+	}
 }
 
-func validateConfig() error {
-	requiredVariables := []string{
-		"DATABASE_URL",
+// SecurityOptions applies the server section over the secure defaults.
+// Per-route overrides are left to the caller.
+func (c *Config) SecurityOptions() server.SecurityOptions {
+	security := server.DefaultSecurityOptions()
+	security.Timeouts = server.Timeouts{
+		ReadHeader: c.Server.ReadHeaderTimeout,
+		Read:       c.Server.ReadTimeout,
+		Write:      c.Server.WriteTimeout,
+		Idle:       c.Server.IdleTimeout,
 	}
+	security.BodyLimit = int64(c.Server.BodyLimit)
+	security.Headers.HSTSMaxAge = c.Server.HSTSMaxAge
+	security.Headers.ContentSecurityPolicy = c.Server.ContentSecurityPolicy
+	security.CORS.AllowedOrigins = c.Server.CORSAllowedOrigins
+	security.CORS.AllowCredentials = c.Server.CORSAllowCredentials
+	return security
+}
 
-	for _, variable := range requiredVariables {
-		if os.Getenv(variable) == "" {
-			return fmt.Errorf("%w: %s", ErrRequiredVariableNotSet, variable)
-		}
+// SessionOptions converts the validated cookie settings of the auth section
+// for api.NewSessions.
+func (c *Config) SessionOptions() api.SessionOptions {
+	sameSite, _ := api.ParseSameSite(c.Auth.CookieSameSite)
+	return api.SessionOptions{
+		Domain:   c.Auth.CookieDomain,
+		Path:     c.Auth.CookiePath,
+		SameSite: sameSite,
+		Secure:   c.Auth.CookieSecure,
 	}
-	return nil
 }
 
-var ErrRequiredVariableNotSet = errors.New("required variable not set")
+// LoadDotenv copies the variables of a dotenv file into the environment.
+// The file is optional.
+func LoadDotenv(filePath string) error {
+	err := godotenv.Load(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,349 @@
+package config
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/secrets"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FileFlag     = "config"
+	FileVariable = "CONFIG_FILE"
+	SecretsFlag  = "secrets"
+
+	fileSuffix = "_FILE"
+)
+
+// rawValue is a value as read from one source. The environment and the flags
+// only provide text, which list fields split on commas; files can also give
+// the items of a list.
+type rawValue struct {
+	text  string
+	items []string
+	list  bool
+}
+
+// field is a leaf of Config, addressed by its dotted key.
+type field struct {
+	value      reflect.Value
+	key        string
+	env        string
+	reloadable bool
+}
+
+// Load builds the configuration from, in increasing order of precedence,
+// the defaults, the optional file named by -config or CONFIG_FILE, the
+// environment and the flags in args. It registers one flag per key, plus
+// -config and -secrets, on flags before parsing args. Every invalid value is
+// reported, joined into a single error.
+func Load(flags *flag.FlagSet, args []string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	cfg := Defaults()
+	fields := fieldsOf(cfg)
+
+	filePath, _ := lookupEnv(FileVariable)
+	flags.StringVar(&filePath, FileFlag, filePath, "YAML or TOML configuration file")
+	secretsPath, _ := lookupEnv(secrets.FileVariable)
+	flags.StringVar(&secretsPath, SecretsFlag, secretsPath, "encrypted secrets file that secret:// values refer to")
+
+	flagValues := map[string]rawValue{}
+	for _, f := range fields {
+		flags.Func(f.key, "env "+f.env, func(value string) error {
+			flagValues[f.key] = rawValue{text: value}
+			return nil
+		})
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	var fileValues map[string]rawValue
+	if filePath != "" {
+		var err error
+		fileValues, err = readFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	envValues, envErrs := readEnv(fields, lookupEnv)
+	errs = append(errs, envErrs...)
+
+	resolver := &secretResolver{path: secretsPath, lookupEnv: lookupEnv}
+	for _, values := range []map[string]rawValue{fileValues, envValues, flagValues} {
+		errs = append(errs, apply(fields, values, resolver)...)
+	}
+
+	errs = append(errs, cfg.validate()...)
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	cfg.file = filePath
+	return cfg, nil
+}
+
+// LoadEnv is Load without flags, for commands that parse their own.
+func LoadEnv() (*Config, error) {
+	flags := flag.NewFlagSet("config", flag.ContinueOnError)
+	return Load(flags, nil, os.LookupEnv)
+}
+
+func fieldsOf(cfg *Config) []field {
+	var fields []field
+	sections := reflect.ValueOf(cfg).Elem()
+	for i := range sections.NumField() {
+		section := sections.Field(i)
+		sectionKey := sections.Type().Field(i).Tag.Get("config")
+		if sectionKey == "" {
+			continue
+		}
+		for j := range section.NumField() {
+			tag := section.Type().Field(j).Tag
+			key := sectionKey + "." + tag.Get("config")
+			env := tag.Get("env")
+			if env == "" {
+				env = strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+			}
+			fields = append(fields, field{
+				value:      section.Field(j),
+				key:        key,
+				env:        env,
+				reloadable: tag.Get("reload") == "true",
+			})
+		}
+	}
+	return fields
+}
+
+// readEnv reads the variable of every field, or the file named by the same
+// variable suffixed with _FILE as Docker and Kubernetes mount secrets.
+func readEnv(fields []field, lookupEnv func(string) (string, bool)) (map[string]rawValue, []error) {
+	values := map[string]rawValue{}
+	var errs []error
+	for _, f := range fields {
+		value, ok := lookupEnv(f.env)
+		valuePath, fromFile := lookupEnv(f.env + fileSuffix)
+		switch {
+		case ok && fromFile:
+			errs = append(errs, fmt.Errorf("%s: %w: %s and %s", f.key, ErrConflictingVariables, f.env, f.env+fileSuffix))
+		case fromFile:
+			contents, err := os.ReadFile(valuePath)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", f.key, err))
+				continue
+			}
+			values[f.key] = rawValue{text: strings.TrimRight(string(contents), "\r\n")}
+		case ok:
+			values[f.key] = rawValue{text: value}
+		}
+	}
+	return values, errs
+}
+
+// apply sets every value on the field with the same key. Keys are applied
+// in order so that errors are reported deterministically.
+func apply(fields []field, values map[string]rawValue, resolver *secretResolver) []error {
+	byKey := make(map[string]field, len(fields))
+	for _, f := range fields {
+		byKey[f.key] = f
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		f, ok := byKey[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrUnknownKey, key))
+			continue
+		}
+		value := values[key]
+		if value.list {
+			if err := applyList(f.value, value.items, resolver); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+			}
+			continue
+		}
+		if secrets.IsReference(value.text) {
+			resolved, err := resolver.resolve(value.text)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+				continue
+			}
+			// The value is kept out of the error below, which would
+			// otherwise leak the secret when it fails to parse.
+			if err := set(f.value, resolved); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w: %s", key, err, value.text))
+			}
+			continue
+		}
+		if err := set(f.value, value.text); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w: %q", key, err, value.text))
+		}
+	}
+	return errs
+}
+
+// applyList sets the items of a list read from a file as they are, commas
+// included, resolving the secret references among them.
+func applyList(value reflect.Value, items []string, resolver *secretResolver) error {
+	// An empty list leaves the field nil, like an empty variable does.
+	var resolved []string
+	for _, item := range items {
+		if secrets.IsReference(item) {
+			var err error
+			if item, err = resolver.resolve(item); err != nil {
+				return err
+			}
+		}
+		resolved = append(resolved, item)
+	}
+
+	switch value.Interface().(type) {
+	case []string:
+		value.Set(reflect.ValueOf(resolved))
+	case []Secret:
+		var values []Secret
+		for _, item := range resolved {
+			values = append(values, Secret(item))
+		}
+		value.Set(reflect.ValueOf(values))
+	default:
+		return fmt.Errorf("%w: a list is not allowed here", ErrInvalidValue)
+	}
+	return nil
+}
+
+func set(value reflect.Value, raw string) error {
+	if unmarshaler, ok := value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalText([]byte(raw)); err != nil {
+			return ErrInvalidValue
+		}
+		return nil
+	}
+
+	switch value.Interface().(type) {
+	case time.Duration:
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return ErrInvalidDuration
+		}
+		value.SetInt(int64(duration))
+	case string, Secret:
+		value.SetString(raw)
+	case int:
+		number, err := strconv.Atoi(raw)
+		if err != nil {
+			return ErrInvalidValue
+		}
+		value.SetInt(int64(number))
+	case bool:
+		boolean, err := strconv.ParseBool(raw)
+		if err != nil {
+			return ErrInvalidValue
+		}
+		value.SetBool(boolean)
+	case []string:
+		value.Set(reflect.ValueOf(splitList(raw)))
+	case []Secret:
+		items := splitList(raw)
+		values := make([]Secret, len(items))
+		for i, item := range items {
+			values[i] = Secret(item)
+		}
+		value.Set(reflect.ValueOf(values))
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return nil
+}
+
+// readFile flattens a YAML or TOML file into dotted keys, so that it goes
+// through the same parsing as the environment and the flags.
+func readFile(filePath string) (map[string]rawValue, error) {
+	contents, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var document map[string]any
+	switch filepath.Ext(filePath) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(contents, &document)
+	case ".toml":
+		err = toml.Unmarshal(contents, &document)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFile, filePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+
+	values := map[string]rawValue{}
+	if err := flatten("", document, values); err != nil {
+		return nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+	return values, nil
+}
+
+func flatten(prefix string, document map[string]any, values map[string]rawValue) error {
+	var errs []error
+	for key, value := range document {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch value := value.(type) {
+		case map[string]any:
+			errs = append(errs, flatten(key, value, values))
+		case []any:
+			items := make([]string, len(value))
+			for i, item := range value {
+				switch item.(type) {
+				case map[string]any, []any:
+					errs = append(errs, fmt.Errorf("%s[%d]: %w: list items must be scalars", key, i, ErrInvalidValue))
+				default:
+					items[i] = fmt.Sprint(item)
+				}
+			}
+			values[key] = rawValue{items: items, list: true}
+		default:
+			values[key] = rawValue{text: fmt.Sprint(value)}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+var (
+	ErrUnknownKey      = errors.New("unknown configuration key")
+	ErrUnsupportedFile = errors.New("unsupported configuration file")
+	ErrInvalidValue    = errors.New("invalid value")
+	ErrInvalidDuration = errors.New("invalid duration")
+
+	ErrConflictingVariables = errors.New("variable set both directly and from a file")
+)
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Print writes the configuration as YAML that Load accepts back as a file.
+// Secret values are redacted by their own encoding.
+func (c *Config) Print(w io.Writer) error {
+	document := map[string]map[string]any{}
+	for _, f := range fieldsOf(c) {
+		section, key, _ := strings.Cut(f.key, ".")
+		if document[section] == nil {
+			document[section] = map[string]any{}
+		}
+
+		value := f.value.Interface()
+		if stringer, ok := value.(fmt.Stringer); ok {
+			value = stringer.String()
+		}
+		document[section][key] = value
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
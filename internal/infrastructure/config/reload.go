@@ -0,0 +1,144 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDebounce coalesces the bursts of events editors and Kubernetes
+// produce when they replace a file.
+const reloadDebounce = 100 * time.Millisecond
+
+// Changes lists the keys a reload changed. Applied keys are live; the
+// others keep their running value until the application restarts.
+type Changes struct {
+	Applied         []string
+	RestartRequired []string
+}
+
+// Reloader holds the running configuration and replaces its reloadable
+// fields when the configuration is loaded again. Readers see either the
+// old or the new configuration as a whole, never a mix.
+type Reloader struct {
+	current     atomic.Pointer[Config]
+	load        func() (*Config, error)
+	logger      *slog.Logger
+	subscribers []func(cfg *Config)
+	mu          sync.Mutex
+}
+
+// NewReloader starts from cfg and reloads with load, which should read the
+// same sources Load read cfg from.
+func NewReloader(cfg *Config, load func() (*Config, error), logger *slog.Logger) *Reloader {
+	r := &Reloader{load: load, logger: logger}
+	r.current.Store(cfg)
+	return r
+}
+
+func (r *Reloader) Current() *Config {
+	return r.current.Load()
+}
+
+// Subscribe registers fn to be called with the new configuration after
+// every reload that applies a change.
+func (r *Reloader) Subscribe(fn func(cfg *Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscribers = append(r.subscribers, fn)
+}
+
+// Reload loads the configuration again. An invalid configuration is
+// returned as an error and the running one stays in place.
+func (r *Reloader) Reload() (Changes, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loaded, err := r.load()
+	if err != nil {
+		return Changes{}, err
+	}
+
+	next := *r.current.Load()
+	loadedFields := fieldsOf(loaded)
+	var changes Changes
+	for i, f := range fieldsOf(&next) {
+		if reflect.DeepEqual(f.value.Interface(), loadedFields[i].value.Interface()) {
+			continue
+		}
+		if !f.reloadable {
+			changes.RestartRequired = append(changes.RestartRequired, f.key)
+			continue
+		}
+		f.value.Set(loadedFields[i].value)
+		changes.Applied = append(changes.Applied, f.key)
+	}
+
+	if len(changes.Applied) > 0 {
+		r.current.Store(&next)
+		for _, fn := range r.subscribers {
+			fn(&next)
+		}
+	}
+	return changes, nil
+}
+
+// Watch reloads whenever signals receives, typically SIGHUP, and whenever
+// the directory of the configuration file changes, until ctx is done.
+func (r *Reloader) Watch(ctx context.Context, signals <-chan os.Signal) error {
+	var events <-chan fsnotify.Event
+	var watchErrors <-chan error
+	if file := r.Current().File(); file != "" {
+		// The directory is watched rather than the file, which editors and
+		// Kubernetes replace instead of writing to.
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return err
+		}
+		defer watcher.Close()
+		if err := watcher.Add(filepath.Dir(file)); err != nil {
+			return err
+		}
+		events, watchErrors = watcher.Events, watcher.Errors
+	}
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-signals:
+			r.reload("signal")
+		case event := <-events:
+			if !event.Has(fsnotify.Chmod) {
+				debounce = time.After(reloadDebounce)
+			}
+		case <-debounce:
+			debounce = nil
+			r.reload("file")
+		case err := <-watchErrors:
+			r.logger.Warn("configuration watch failed", "error", err)
+		}
+	}
+}
+
+func (r *Reloader) reload(trigger string) {
+	changes, err := r.Reload()
+	if err != nil {
+		r.logger.Error("configuration reload failed, keeping the running configuration", "trigger", trigger, "error", err)
+		return
+	}
+	if len(changes.RestartRequired) > 0 {
+		r.logger.Warn("configuration changes require a restart", "trigger", trigger, "keys", changes.RestartRequired)
+	}
+	if len(changes.Applied) > 0 {
+		r.logger.Info("configuration reloaded", "trigger", trigger, "keys", changes.Applied)
+	}
+}
@@ -0,0 +1,150 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/config"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloader_Reload(t *testing.T) {
+	errInvalid := errors.New("log.level: invalid value")
+
+	tests := []struct {
+		expectedErr     error
+		name            string
+		env             map[string]string
+		expectedChanges config.Changes
+		expectedLevel   slog.Level
+		expectedPort    int
+		expectedNotify  bool
+		invalid         bool
+	}{
+		{
+			name:          "Nothing changed",
+			env:           map[string]string{},
+			expectedLevel: slog.LevelInfo,
+			expectedPort:  3000,
+		},
+		{
+			name:            "Reloadable field applied",
+			env:             map[string]string{"LOG_LEVEL": "debug"},
+			expectedChanges: config.Changes{Applied: []string{"log.level"}},
+			expectedLevel:   slog.LevelDebug,
+			expectedPort:    3000,
+			expectedNotify:  true,
+		},
+		{
+			name: "Restart required fields reported, not applied",
+			env:  map[string]string{"LOG_LEVEL": "warn", "SERVER_PORT": "8080"},
+			expectedChanges: config.Changes{
+				Applied:         []string{"log.level"},
+				RestartRequired: []string{"server.port"},
+			},
+			expectedLevel:  slog.LevelWarn,
+			expectedPort:   3000,
+			expectedNotify: true,
+		},
+		{
+			name:            "Email alias folding requires a restart",
+			env:             map[string]string{"CANONICALIZE_EMAIL_ALIASES": "true"},
+			expectedChanges: config.Changes{RestartRequired: []string{"email.canonicalize_aliases"}},
+			expectedLevel:   slog.LevelInfo,
+			expectedPort:    3000,
+		},
+		{
+			name:          "Invalid configuration keeps the running one",
+			invalid:       true,
+			expectedErr:   errInvalid,
+			expectedLevel: slog.LevelInfo,
+			expectedPort:  3000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := map[string]string{"DATABASE_URL": "postgres://localhost"}
+			initial, err := load(nil, env)
+			require.NoError(t, err)
+
+			reloader := config.NewReloader(initial, func() (*config.Config, error) {
+				if tt.invalid {
+					return nil, errInvalid
+				}
+				for key, value := range tt.env {
+					env[key] = value
+				}
+				return load(nil, env)
+			}, logging.Discard())
+			var notified *config.Config
+			reloader.Subscribe(func(cfg *config.Config) { notified = cfg })
+
+			changes, err := reloader.Reload()
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedChanges, changes)
+			assert.Equal(t, tt.expectedLevel, reloader.Current().Log.Level)
+			assert.Equal(t, tt.expectedPort, reloader.Current().Server.Port)
+			if tt.expectedNotify {
+				assert.Same(t, reloader.Current(), notified)
+			} else {
+				assert.Nil(t, notified)
+			}
+			assert.Equal(t, slog.LevelInfo, initial.Log.Level, "the running configuration is never mutated")
+		})
+	}
+}
+
+func TestReloader_Watch(t *testing.T) {
+	file := writeFile(t, "config.yaml", "log:\n  level: info\n")
+	env := map[string]string{"DATABASE_URL": "postgres://localhost", config.FileVariable: file}
+	initial, err := load(nil, env)
+	require.NoError(t, err)
+
+	var envMu sync.Mutex
+	reloader := config.NewReloader(initial, func() (*config.Config, error) {
+		envMu.Lock()
+		defer envMu.Unlock()
+		return load(nil, env)
+	}, logging.Discard())
+	levels := make(chan slog.Level, 2)
+	reloader.Subscribe(func(cfg *config.Config) { levels <- cfg.Log.Level })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	stopped := make(chan error)
+	go func() { stopped <- reloader.Watch(ctx, signals) }()
+
+	require.Eventually(t, func() bool {
+		require.NoError(t, os.WriteFile(file, []byte("log:\n  level: debug\n"), 0o600))
+		select {
+		case level := <-levels:
+			return level == slog.LevelDebug
+		case <-time.After(200 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	envMu.Lock()
+	env["LOG_LEVEL"] = "error"
+	envMu.Unlock()
+	signals <- syscall.SIGHUP
+	select {
+	case level := <-levels:
+		assert.Equal(t, slog.LevelError, level)
+	case <-time.After(5 * time.Second):
+		t.Fatal("reload on signal timed out")
+	}
+
+	cancel()
+	assert.NoError(t, <-stopped)
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/secrets"
+)
+
+// Secret holds a sensitive value. Formatting, logging and JSON encoding all
+// redact it; only Value returns the value itself.
+type Secret string
+
+func (s Secret) Value() string {
+	return string(s)
+}
+
+// String reports an unset secret as empty, so that a missing value is still
+// visible when the configuration is printed.
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return logging.Redacted
+}
+
+func (s Secret) GoString() string {
+	return s.String()
+}
+
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
+
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Values returns the values of secrets, for the components that need them
+// in the clear.
+func Values(secrets []Secret) []string {
+	values := make([]string, len(secrets))
+	for i, secret := range secrets {
+		values[i] = secret.Value()
+	}
+	return values
+}
+
+// secretResolver opens the secrets store on the first secret:// reference,
+// so that configurations without references need neither the file nor the
+// key.
+type secretResolver struct {
+	store     *secrets.Store
+	lookupEnv func(string) (string, bool)
+	path      string
+}
+
+func (r *secretResolver) resolve(reference string) (string, error) {
+	if r.store == nil {
+		store, err := r.open()
+		if err != nil {
+			return "", err
+		}
+		r.store = store
+	}
+	return r.store.Resolve(reference)
+}
+
+func (r *secretResolver) open() (*secrets.Store, error) {
+	if r.path == "" {
+		return nil, fmt.Errorf("%w: %s", ErrRequiredValueNotSet, secrets.FileVariable)
+	}
+	key, err := ReadSecretsKey(r.lookupEnv)
+	if err != nil {
+		return nil, err
+	}
+	return secrets.Open(r.path, key)
+}
+
+// ReadSecretsKey reads the store key from SECRETS_KEY or from the file
+// named by SECRETS_KEY_FILE.
+func ReadSecretsKey(lookupEnv func(string) (string, bool)) (*secrets.Key, error) {
+	if keyPath, ok := lookupEnv(secrets.KeyVariable + fileSuffix); ok {
+		contents, err := os.ReadFile(keyPath)
+		if err != nil {
+			return nil, err
+		}
+		return secrets.ParseKey(string(contents))
+	}
+	encoded, ok := lookupEnv(secrets.KeyVariable)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrRequiredValueNotSet, secrets.KeyVariable)
+	}
+	return secrets.ParseKey(encoded)
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/security"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/tracing"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/server"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// validate returns every violation rather than stopping at the first one,
+// so that a broken deployment is fixed in a single round.
+func (c *Config) validate() []error {
+	var errs []error
+	check := func(ok bool, key string, err error, value any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %w: %v", key, err, value))
+		}
+	}
+	positive := func(key string, value time.Duration) {
+		check(value > 0, key, ErrInvalidDuration, value)
+	}
+
+	check(validPort(c.Server.Port), "server.port", ErrInvalidPort, c.Server.Port)
+	positive("server.read_header_timeout", c.Server.ReadHeaderTimeout)
+	positive("server.read_timeout", c.Server.ReadTimeout)
+	positive("server.write_timeout", c.Server.WriteTimeout)
+	positive("server.idle_timeout", c.Server.IdleTimeout)
+	positive("server.shutdown_timeout", c.Server.ShutdownTimeout)
+	// The components need part of the shutdown timeout to stop.
+	check(c.Server.DrainDelay >= 0 && c.Server.DrainDelay < c.Server.ShutdownTimeout,
+		"server.drain_delay", ErrInvalidDuration, c.Server.DrainDelay)
+	positive("server.readiness_timeout", c.Server.ReadinessTimeout)
+	check(c.Server.BodyLimit > 0, "server.body_limit", ErrInvalidValue, c.Server.BodyLimit)
+	check(c.Server.HSTSMaxAge >= 0, "server.hsts_max_age", ErrInvalidDuration, c.Server.HSTSMaxAge)
+
+	check(c.TLS.CertFile == "" || c.TLS.KeyFile != "", "tls.key_file", ErrRequiredValueNotSet, "TLS_KEY_FILE")
+	check(c.TLS.KeyFile == "" || c.TLS.CertFile != "", "tls.cert_file", ErrRequiredValueNotSet, "TLS_CERT_FILE")
+	check(c.TLS.ClientCAFile == "" || c.TLS.CertFile != "", "tls.client_ca_file", ErrRequiredValueNotSet, "TLS_CERT_FILE")
+	if _, err := server.ParseTLSVersion(c.TLS.MinVersion); err != nil {
+		errs = append(errs, fmt.Errorf("tls.min_version: %w", err))
+	}
+	if _, err := server.ParseCipherSuites(c.TLS.CipherSuites); err != nil {
+		errs = append(errs, fmt.Errorf("tls.cipher_suites: %w", err))
+	}
+
+	check(c.Database.URL != "", "database.url", ErrRequiredValueNotSet, "DATABASE_URL")
+	check(c.Database.MaxOpenConns >= 0, "database.max_open_conns", ErrInvalidValue, c.Database.MaxOpenConns)
+	check(c.Database.MaxIdleConns >= 0, "database.max_idle_conns", ErrInvalidValue, c.Database.MaxIdleConns)
+	check(c.Database.ConnMaxLifetime >= 0, "database.conn_max_lifetime", ErrInvalidDuration, c.Database.ConnMaxLifetime)
+	check(c.Database.ConnMaxIdleTime >= 0, "database.conn_max_idle_time", ErrInvalidDuration, c.Database.ConnMaxIdleTime)
+
+	positive("auth.access_token_ttl", c.Auth.AccessTokenTTL)
+	// The key itself is kept out of the error.
+	check(c.Auth.SigningKey == "" || len(c.Auth.SigningKey) >= security.MinSigningKeyLength,
+		"auth.signing_key", security.ErrSigningKeyTooShort, len(c.Auth.SigningKey))
+	check(c.Auth.CookiePath != "", "auth.cookie_path", ErrRequiredValueNotSet, "AUTH_COOKIE_PATH")
+	if sameSite, err := api.ParseSameSite(c.Auth.CookieSameSite); err != nil {
+		errs = append(errs, fmt.Errorf("auth.cookie_same_site: %w", err))
+	} else {
+		check(sameSite != http.SameSiteNoneMode || c.Auth.CookieSecure,
+			"auth.cookie_same_site", ErrInsecureSameSite, c.Auth.CookieSameSite)
+	}
+
+	check(c.Hashing.BcryptCost >= bcrypt.MinCost && c.Hashing.BcryptCost <= bcrypt.MaxCost,
+		"hashing.bcrypt_cost", ErrInvalidValue, c.Hashing.BcryptCost)
+
+	check(c.Log.Format == logging.FormatJSON || c.Log.Format == logging.FormatText,
+		"log.format", ErrInvalidLogFormat, c.Log.Format)
+
+	switch c.Trace.Exporter {
+	case tracing.ExporterNone, tracing.ExporterOTLP, tracing.ExporterStdout:
+	default:
+		check(false, "trace.exporter", ErrInvalidTraceExporter, c.Trace.Exporter)
+	}
+
+	if c.GRPC.Port != 0 {
+		check(validPort(c.GRPC.Port), "grpc.port", ErrInvalidPort, c.GRPC.Port)
+		check(len(c.GRPC.APIKeys) > 0, "grpc.api_keys", ErrRequiredValueNotSet, "GRPC_API_KEYS")
+		// The API keys would otherwise travel in plaintext.
+		check(c.TLS.CertFile != "", "grpc.port", ErrGRPCWithoutTLS, c.GRPC.Port)
+	}
+	if c.Metrics.Address != "" {
+		check(validAddress(c.Metrics.Address), "metrics.address", ErrInvalidAddress, c.Metrics.Address)
+	}
+	return errs
+}
+
+func validAddress(address string) bool {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	number, err := strconv.Atoi(port)
+	return err == nil && validPort(number)
+}
+
+func validPort(port int) bool {
+	return port >= 1 && port <= 65535
+}
+
+var (
+	ErrRequiredValueNotSet  = errors.New("required value not set")
+	ErrInvalidPort          = errors.New("invalid port")
+	ErrInvalidAddress       = errors.New("invalid address")
+	ErrInvalidLogFormat     = errors.New("invalid log format")
+	ErrInvalidTraceExporter = errors.New("invalid trace exporter")
+	ErrInsecureSameSite     = errors.New("SameSite none requires auth.cookie_secure")
+	ErrGRPCWithoutTLS       = errors.New("gRPC requires tls.cert_file")
+)
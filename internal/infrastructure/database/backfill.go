@@ -0,0 +1,192 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+)
+
+// createUsernameRenames records the users renamed by
+// backfillUsernameSkeletons, for operators to review and notify.
+const createUsernameRenames = `CREATE TABLE IF NOT EXISTS username_renames (
+	user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	old_username TEXT NOT NULL,
+	new_username TEXT NOT NULL,
+	renamed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`
+
+type usernameRow struct {
+	Username string `db:"username"`
+	ID       int    `db:"id"`
+}
+
+// backfillUsernameSkeletons computes every username_skeleton with
+// domain.UsernameSkeleton, then makes the column required and unique. It
+// also repairs databases where an earlier version of the migration filled
+// the column with an SQL approximation. Usernames that were registered
+// before skeletons existed may look alike; the oldest account keeps its
+// username and the others are renamed by appending their ID, each rename
+// being recorded in username_renames.
+func backfillUsernameSkeletons(tx *sqlx.Tx) error {
+	for _, statement := range []string{
+		"ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_skeleton_key",
+		"ALTER TABLE users ALTER COLUMN username_skeleton DROP NOT NULL",
+		createUsernameRenames,
+	} {
+		if _, err := tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+
+	var rows []usernameRow
+	if err := tx.Select(&rows, "SELECT id, username FROM users ORDER BY id"); err != nil {
+		return err
+	}
+
+	// The first pass settles which users keep their username, so that a
+	// new name never takes the skeleton of a user processed later.
+	taken := make(map[string]bool, len(rows))
+	var renamed []usernameRow
+	for _, row := range rows {
+		skeleton := domain.UsernameSkeleton(row.Username)
+		if taken[skeleton] {
+			renamed = append(renamed, row)
+			continue
+		}
+		taken[skeleton] = true
+		if _, err := tx.Exec("UPDATE users SET username_skeleton = $1 WHERE id = $2", skeleton, row.ID); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range renamed {
+		username, err := renameUsername(row, taken)
+		if err != nil {
+			return err
+		}
+		skeleton := domain.UsernameSkeleton(username)
+		taken[skeleton] = true
+		if _, err := tx.Exec("UPDATE users SET username = $1, username_skeleton = $2 WHERE id = $3", username, skeleton, row.ID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec("INSERT INTO username_renames (user_id, old_username, new_username) VALUES ($1, $2, $3)", row.ID, row.Username, username); err != nil {
+			return err
+		}
+	}
+
+	for _, statement := range []string{
+		"ALTER TABLE users ALTER COLUMN username_skeleton SET NOT NULL",
+		"ALTER TABLE users ADD CONSTRAINT users_username_skeleton_key UNIQUE (username_skeleton)",
+	} {
+		if _, err := tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renameUsername appends the user's ID, and a counter if needed, keeping
+// the result within domain.UsernameMaxLength.
+func renameUsername(row usernameRow, taken map[string]bool) (string, error) {
+	base := []rune(row.Username)
+	for attempt := 0; attempt < 100; attempt++ {
+		suffix := "_" + strconv.Itoa(row.ID)
+		if attempt > 0 {
+			suffix += "_" + strconv.Itoa(attempt)
+		}
+		prefix := base[:min(len(base), max(domain.UsernameMaxLength-len(suffix), 0))]
+		username := string(prefix) + suffix
+		if !taken[domain.UsernameSkeleton(username)] {
+			return username, nil
+		}
+	}
+	return "", fmt.Errorf("%w: user %d", ErrUsernameRenameFailed, row.ID)
+}
+
+// createEmailConflicts records the users whose email
+// backfillNormalizedEmails left unchanged, for operators to review.
+const createEmailConflicts = `CREATE TABLE IF NOT EXISTS email_conflicts (
+	user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	email TEXT NOT NULL,
+	reason TEXT NOT NULL,
+	recorded_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`
+
+const (
+	// emailConflictDuplicate marks an email that normalizes to the email
+	// of another user.
+	emailConflictDuplicate = "duplicate"
+	// emailConflictInvalid marks an email that no longer parses.
+	emailConflictInvalid = "invalid"
+)
+
+type emailRow struct {
+	Email string `db:"email"`
+	ID    int    `db:"id"`
+}
+
+// backfillNormalizedEmails rewrites every email into domain.Email.Normalized
+// form, which Signup stores and Login looks up, under the email policy in
+// effect when the migration runs. Emails registered before normalization
+// may normalize alike: the user already storing the normalized form keeps
+// it, since that is the account Login finds today, and otherwise the
+// oldest user does. The other users, and those whose email no longer
+// parses, keep their email unchanged and are recorded in email_conflicts.
+func backfillNormalizedEmails(tx *sqlx.Tx) error {
+	if _, err := tx.Exec(createEmailConflicts); err != nil {
+		return err
+	}
+
+	var rows []emailRow
+	if err := tx.Select(&rows, "SELECT id, email FROM users ORDER BY id"); err != nil {
+		return err
+	}
+
+	// The first pass settles which user owns each normalized email, so
+	// that no update collides with an email changed later.
+	normalized := make(map[int]string, len(rows))
+	owners := make(map[string]emailRow, len(rows))
+	for _, row := range rows {
+		email, err := (&domain.Email{Value: row.Email}).Normalized()
+		if err != nil {
+			if err := recordEmailConflict(tx, row, emailConflictInvalid); err != nil {
+				return err
+			}
+			continue
+		}
+		normalized[row.ID] = email
+		if _, ok := owners[email]; !ok || row.Email == email {
+			owners[email] = row
+		}
+	}
+
+	for _, row := range rows {
+		email, ok := normalized[row.ID]
+		if !ok {
+			continue
+		}
+		if owners[email].ID != row.ID {
+			if err := recordEmailConflict(tx, row, emailConflictDuplicate); err != nil {
+				return err
+			}
+			continue
+		}
+		if row.Email == email {
+			continue
+		}
+		if _, err := tx.Exec("UPDATE users SET email = $1 WHERE id = $2", email, row.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func recordEmailConflict(tx *sqlx.Tx, row emailRow, reason string) error {
+	_, err := tx.Exec("INSERT INTO email_conflicts (user_id, email, reason) VALUES ($1, $2, $3)", row.ID, row.Email, reason)
+	return err
+}
+
+var ErrUsernameRenameFailed = errors.New("no free username to rename to")
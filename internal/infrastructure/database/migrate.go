@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version TEXT PRIMARY KEY,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`
+
+// goMigrations are the migrations that need Go code, keyed by version. They
+// are ordered with the embedded SQL files by version.
+var goMigrations = map[string]func(tx *sqlx.Tx) error{
+	"0005_backfill_username_skeletons": backfillUsernameSkeletons,
+	"0006_normalize_emails":            backfillNormalizedEmails,
+}
+
+// Migrate applies every migration that has not been recorded in
+// schema_migrations yet, in lexical order, each in its own transaction.
+func Migrate(db *sqlx.DB) error {
+	if _, err := db.Exec(createMigrationsTable); err != nil {
+		return err
+	}
+
+	versions, err := migrationVersions()
+	if err != nil {
+		return err
+	}
+
+	for _, version := range versions {
+		var applied bool
+		err := db.Get(&applied, "SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", version)
+		if err != nil {
+			return err
+		}
+		if applied {
+			continue
+		}
+		if err := applyMigration(db, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyMigration(db *sqlx.DB, version string) error {
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if migrate, ok := goMigrations[version]; ok {
+		err = migrate(tx)
+	} else {
+		err = applySQLMigration(tx, version)
+	}
+	if err != nil {
+		return fmt.Errorf("migration %s: %w", version, err)
+	}
+	if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES ($1)", version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func applySQLMigration(tx *sqlx.Tx, version string) error {
+	contents, err := migrationFiles.ReadFile("migrations/" + version + ".sql")
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(string(contents))
+	return err
+}
+
+func migrationVersions() ([]string, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, 0, len(entries)+len(goMigrations))
+	for _, entry := range entries {
+		versions = append(versions, strings.TrimSuffix(entry.Name(), ".sql"))
+	}
+	for version := range goMigrations {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return versions, nil
+}
+
+// CheckMigrations reports ErrPendingMigrations when any embedded migration
+// has not been recorded in schema_migrations.
+func CheckMigrations(ctx context.Context, db sqlx.QueryerContext) error {
+	var applied []string
+	if err := sqlx.SelectContext(ctx, db, &applied, "SELECT version FROM schema_migrations"); err != nil {
+		return err
+	}
+
+	versions, err := migrationVersions()
+	if err != nil {
+		return err
+	}
+
+	pending := make([]string, 0, len(versions))
+	for _, version := range versions {
+		if !slices.Contains(applied, version) {
+			pending = append(pending, version)
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %s", ErrPendingMigrations, strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+var ErrPendingMigrations = errors.New("pending migrations")
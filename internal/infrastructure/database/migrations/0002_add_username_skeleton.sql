@@ -0,0 +1,4 @@
+-- The column is filled in and constrained by
+-- 0005_backfill_username_skeletons, which computes the skeletons with
+-- domain.UsernameSkeleton rather than an SQL approximation of it.
+ALTER TABLE users ADD COLUMN username_skeleton TEXT;
@@ -0,0 +1,10 @@
+package database
+
+import (
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+)
+
+func NewSQLite(dataSourceName string) (*sqlx.DB, error) {
+	return sqlx.Connect("sqlite", dataSourceName)
+}
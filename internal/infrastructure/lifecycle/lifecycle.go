@@ -0,0 +1,150 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+)
+
+const (
+	DefaultShutdownTimeout = 15 * time.Second
+	DefaultDrainDelay      = 5 * time.Second
+)
+
+const (
+	ExitOK    = 0
+	ExitError = 1
+	ExitPanic = 2
+)
+
+type component struct {
+	start func() error
+	stop  func(ctx context.Context) error
+	name  string
+}
+
+// Manager runs the application's components. Components start all at once,
+// each on its own goroutine, so one must not depend on another being up; on
+// shutdown the drain hooks run first, then, after the drain delay, every
+// component is stopped in the reverse order it was added. The drain delay
+// counts against the shutdown timeout.
+type Manager struct {
+	logger          *slog.Logger
+	components      []component
+	drainHooks      []func()
+	shutdownTimeout time.Duration
+	drainDelay      time.Duration
+}
+
+// New returns a Manager that waits drainDelay between the drain hooks and
+// stopping the components, for load balancers to notice the application is
+// no longer ready while it still serves the requests routed to it.
+func New(logger *slog.Logger, shutdownTimeout, drainDelay time.Duration) *Manager {
+	return &Manager{logger: logger, shutdownTimeout: shutdownTimeout, drainDelay: drainDelay}
+}
+
+// Add registers a component. start blocks while the component runs and is
+// called on its own goroutine; when it returns, the whole application shuts
+// down. Either function may be nil: resources such as the database only
+// need stop.
+func (m *Manager) Add(name string, start func() error, stop func(ctx context.Context) error) {
+	m.components = append(m.components, component{name: name, start: start, stop: stop})
+}
+
+// OnDrain registers hook to run as soon as shutdown begins, before any
+// component is stopped.
+func (m *Manager) OnDrain(hook func()) {
+	m.drainHooks = append(m.drainHooks, hook)
+}
+
+// Run starts every component and blocks until ctx is done or a component
+// exits. It returns the error that caused the shutdown, if any, joined with
+// the errors of the components that failed to stop.
+func (m *Manager) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	exited := make(chan error, len(m.components))
+	for _, c := range m.components {
+		if c.start == nil {
+			continue
+		}
+		m.logger.Info("starting component", "component", c.name)
+		go func() {
+			exited <- m.runComponent(c)
+			cancel()
+		}()
+	}
+
+	<-ctx.Done()
+	var cause error
+	select {
+	case cause = <-exited:
+	default:
+	}
+
+	return errors.Join(cause, m.shutdown())
+}
+
+func (m *Manager) runComponent(c component) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.logger.Error("component panicked", "component", c.name, "panic", r, "stack", string(debug.Stack()))
+			err = fmt.Errorf("%s: %w: %v", c.name, ErrPanic, r)
+		}
+	}()
+
+	if err := c.start(); err != nil {
+		return fmt.Errorf("%s: %w", c.name, err)
+	}
+	return nil
+}
+
+func (m *Manager) shutdown() error {
+	m.logger.Info("shutting down", "timeout", m.shutdownTimeout, "drain_delay", m.drainDelay)
+	ctx, cancel := context.WithTimeout(context.Background(), m.shutdownTimeout)
+	defer cancel()
+
+	for _, hook := range m.drainHooks {
+		hook()
+	}
+	if m.drainDelay > 0 {
+		timer := time.NewTimer(m.drainDelay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+		}
+	}
+
+	var errs []error
+	for i := len(m.components) - 1; i >= 0; i-- {
+		c := m.components[i]
+		if c.stop == nil {
+			continue
+		}
+		if err := c.stop(ctx); err != nil {
+			m.logger.Error("component failed to stop", "component", c.name, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", c.name, err))
+			continue
+		}
+		m.logger.Info("component stopped", "component", c.name)
+	}
+	return errors.Join(errs...)
+}
+
+// ExitCode maps the result of Run to a process exit code.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, ErrPanic):
+		return ExitPanic
+	}
+	return ExitError
+}
+
+var ErrPanic = errors.New("panic")
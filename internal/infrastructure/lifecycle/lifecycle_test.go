@@ -0,0 +1,167 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/lifecycle"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockUntilStopped returns a start/stop pair that behaves like a server:
+// start blocks until stop is called.
+func blockUntilStopped(events *[]string, name string) (func() error, func(ctx context.Context) error) {
+	stopped := make(chan struct{})
+	start := func() error {
+		<-stopped
+		return nil
+	}
+	stop := func(context.Context) error {
+		*events = append(*events, "stop "+name)
+		close(stopped)
+		return nil
+	}
+	return start, stop
+}
+
+func TestManager_Run(t *testing.T) {
+	errStart := errors.New("address already in use")
+	errStop := errors.New("close failed")
+
+	tests := []struct {
+		expectedErr      error
+		startHTTP        func() error
+		stopDatabase     func(ctx context.Context) error
+		name             string
+		expectedEvents   []string
+		cancel           bool
+		expectedExitCode int
+	}{
+		{
+			name:             "Signal drains and stops in reverse order",
+			cancel:           true,
+			expectedEvents:   []string{"drain", "stop http", "stop worker", "close database"},
+			expectedExitCode: lifecycle.ExitOK,
+		},
+		{
+			name:             "Component error shuts down",
+			startHTTP:        func() error { return errStart },
+			expectedErr:      errStart,
+			expectedEvents:   []string{"drain", "stop http", "stop worker", "close database"},
+			expectedExitCode: lifecycle.ExitError,
+		},
+		{
+			name:             "Component panic surfaces as exit code",
+			startHTTP:        func() error { panic("nil map") },
+			expectedErr:      lifecycle.ErrPanic,
+			expectedEvents:   []string{"drain", "stop http", "stop worker", "close database"},
+			expectedExitCode: lifecycle.ExitPanic,
+		},
+		{
+			name:             "Stop error is reported",
+			cancel:           true,
+			stopDatabase:     func(context.Context) error { return errStop },
+			expectedErr:      errStop,
+			expectedEvents:   []string{"drain", "stop http", "stop worker"},
+			expectedExitCode: lifecycle.ExitError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []string
+			manager := lifecycle.New(logging.Discard(), time.Second, 0)
+			manager.OnDrain(func() { events = append(events, "drain") })
+
+			stopDatabase := tt.stopDatabase
+			if stopDatabase == nil {
+				stopDatabase = func(context.Context) error {
+					events = append(events, "close database")
+					return nil
+				}
+			}
+			manager.Add("database", nil, stopDatabase)
+
+			startWorker, stopWorker := blockUntilStopped(&events, "worker")
+			manager.Add("worker", startWorker, stopWorker)
+
+			startHTTP, stopHTTP := blockUntilStopped(&events, "http")
+			if tt.startHTTP != nil {
+				startHTTP = tt.startHTTP
+			}
+			manager.Add("http", startHTTP, stopHTTP)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+
+			err := manager.Run(ctx)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedExitCode, lifecycle.ExitCode(err))
+			assert.Equal(t, tt.expectedEvents, events)
+		})
+	}
+}
+
+func TestManager_RunShutdownDeadline(t *testing.T) {
+	manager := lifecycle.New(logging.Discard(), 10*time.Millisecond, 0)
+	manager.Add("http", nil, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := manager.Run(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestManager_RunDrainDelay(t *testing.T) {
+	tests := []struct {
+		name            string
+		shutdownTimeout time.Duration
+		drainDelay      time.Duration
+		expectedWait    time.Duration
+	}{
+		{
+			name:            "Components stop after the drain delay",
+			shutdownTimeout: time.Second,
+			drainDelay:      50 * time.Millisecond,
+			expectedWait:    50 * time.Millisecond,
+		},
+		{
+			name:            "Drain delay is bounded by the shutdown timeout",
+			shutdownTimeout: 50 * time.Millisecond,
+			drainDelay:      time.Minute,
+			expectedWait:    50 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var drainedAt, stoppedAt time.Time
+			manager := lifecycle.New(logging.Discard(), tt.shutdownTimeout, tt.drainDelay)
+			manager.OnDrain(func() { drainedAt = time.Now() })
+			manager.Add("http", nil, func(context.Context) error {
+				stoppedAt = time.Now()
+				return nil
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			require.NoError(t, manager.Run(ctx))
+			assert.GreaterOrEqual(t, stoppedAt.Sub(drainedAt), tt.expectedWait)
+			assert.Less(t, stoppedAt.Sub(drainedAt), tt.expectedWait+time.Second)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type loginPayload struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+func TestNew_RequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, logging.FormatJSON, slog.LevelInfo)
+
+	ctx := logging.ContextWithRequestID(context.Background(), "test-request-id")
+	logger.InfoContext(ctx, "with request ID")
+	logger.With("component", "test").InfoContext(ctx, "with attrs")
+	logger.Info("without request ID")
+	logger.DebugContext(ctx, "below the level")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+
+	var records []map[string]any
+	for _, line := range lines {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	assert.Equal(t, "test-request-id", records[0][logging.RequestIDKey])
+	assert.Equal(t, "test-request-id", records[1][logging.RequestIDKey])
+	assert.Equal(t, "test", records[1]["component"])
+	assert.NotContains(t, records[2], logging.RequestIDKey)
+}
+
+func TestNew_TraceContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, logging.FormatJSON, slog.LevelInfo)
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	logger.InfoContext(ctx, "traced")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", record["trace_id"])
+	assert.Equal(t, "00f067aa0ba902b7", record["span_id"])
+}
+
+func TestNew_Redaction(t *testing.T) {
+	tests := []struct {
+		attr     slog.Attr
+		name     string
+		expected string
+	}{
+		{
+			name:     "Sensitive key",
+			attr:     slog.String("password", "secret_password"),
+			expected: `"password":"[REDACTED]"`,
+		},
+		{
+			name:     "Sensitive key in a group",
+			attr:     slog.Group("request", slog.String("Authorization", "Bearer abc")),
+			expected: `"request":{"Authorization":"[REDACTED]"}`,
+		},
+		{
+			name:     "Struct payload",
+			attr:     slog.Any("payload", &loginPayload{Email: "test@user.com", Password: "secret_password"}),
+			expected: `"payload":{"email":"test@user.com","password":"[REDACTED]"}`,
+		},
+		{
+			name:     "Nested map payload",
+			attr:     slog.Any("payload", map[string]any{"tokens": []any{map[string]any{"access_token": "abc"}}, "id": 1}),
+			expected: `"payload":{"id":1,"tokens":"[REDACTED]"}`,
+		},
+		{
+			name:     "JSON body",
+			attr:     slog.Any("body", json.RawMessage(`{"user":{"password_hash":"$2a$10$abc"},"email":"test@user.com"}`)),
+			expected: `"body":{"email":"test@user.com","user":{"password_hash":"[REDACTED]"}}`,
+		},
+		{
+			name:     "Other values",
+			attr:     slog.String("email", "test@user.com"),
+			expected: `"email":"test@user.com"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := logging.New(&buf, logging.FormatJSON, slog.LevelInfo)
+
+			logger.LogAttrs(context.Background(), slog.LevelInfo, "payload", tt.attr)
+
+			assert.Contains(t, buf.String(), tt.expected)
+			assert.NotContains(t, buf.String(), "secret_password")
+		})
+	}
+}
+
+func TestNew_TextFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, logging.FormatText, slog.LevelInfo)
+
+	logger.Info("text", slog.String("token", "abc"))
+
+	assert.Contains(t, buf.String(), "msg=text token=[REDACTED]")
+}
+
+func TestResolveRequestID(t *testing.T) {
+	tests := []struct {
+		name         string
+		id           string
+		expectedKept bool
+	}{
+		{name: "Safe ID", id: "0b6f7c3e-5d1a-4c8e-9f2b-7a6d5e4c3b2a", expectedKept: true},
+		{name: "Empty ID", id: ""},
+		{name: "Too long", id: strings.Repeat("a", 129)},
+		{name: "Control characters", id: "id\nforged=1"},
+		{name: "Spaces", id: "id forged=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := logging.ResolveRequestID(tt.id)
+			if tt.expectedKept {
+				assert.Equal(t, tt.id, id)
+				return
+			}
+			assert.NotEqual(t, tt.id, id)
+			assert.Len(t, id, 32)
+		})
+	}
+}
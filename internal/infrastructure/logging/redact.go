@@ -0,0 +1,114 @@
+package logging
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are matched case-insensitively against attribute keys and
+// the fields of logged payloads. A key containing one of them is redacted,
+// so "password_hash" and "access_token" are covered too.
+var sensitiveKeys = []string{
+	"password",
+	"token",
+	"secret",
+	"authorization",
+	"cookie",
+	"api_key",
+	"apikey",
+}
+
+func IsSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactAttr(groups []string, attr slog.Attr) slog.Attr {
+	if IsSensitive(attr.Key) {
+		return slog.String(attr.Key, Redacted)
+	}
+	if attr.Value.Kind() == slog.KindAny {
+		attr.Value = slog.AnyValue(Redact(attr.Value.Any()))
+	}
+	return attr
+}
+
+// Redact returns payload with the values of sensitive keys replaced.
+// Structs, maps and slices are converted through their JSON encoding, which
+// is also how the JSON handler would print them; JSON documents held in a
+// []byte or json.RawMessage are redacted in place. Errors and values with
+// their own text representation are returned unchanged.
+func Redact(payload any) any {
+	switch payload := payload.(type) {
+	case []byte:
+		return redactJSON(payload)
+	case json.RawMessage:
+		return redactJSON(payload)
+	case nil, error, fmt.Stringer, encoding.TextMarshaler, slog.LogValuer:
+		return payload
+	}
+
+	value := reflect.ValueOf(payload)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return payload
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+	default:
+		return payload
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return payload
+	}
+	var decoded any
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return payload
+	}
+	return redactValue(decoded)
+}
+
+func redactJSON(b []byte) any {
+	var decoded any
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return b
+	}
+	redacted, err := json.Marshal(redactValue(decoded))
+	if err != nil {
+		return b
+	}
+	return json.RawMessage(redacted)
+}
+
+func redactValue(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, nested := range value {
+			if IsSensitive(key) {
+				value[key] = Redacted
+				continue
+			}
+			value[key] = redactValue(nested)
+		}
+	case []any:
+		for i, nested := range value {
+			value[i] = redactValue(nested)
+		}
+	}
+	return value
+}
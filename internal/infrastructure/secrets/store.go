@@ -0,0 +1,169 @@
+package secrets
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+const (
+	// Scheme prefixes configuration values that name a secret in the store,
+	// such as secret://database_url.
+	Scheme = "secret://"
+
+	KeyVariable  = "SECRETS_KEY"
+	FileVariable = "SECRETS_FILE"
+
+	keySize   = 32
+	nonceSize = 24
+)
+
+type Key [keySize]byte
+
+func GenerateKey() (*Key, error) {
+	var key Key
+	if _, err := rand.Read(key[:]); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// ParseKey decodes a key in the base64 form String produces.
+func ParseKey(encoded string) (*Key, error) {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil || len(decoded) != keySize {
+		return nil, ErrInvalidKey
+	}
+	var key Key
+	copy(key[:], decoded)
+	return &key, nil
+}
+
+func (k *Key) String() string {
+	return base64.StdEncoding.EncodeToString(k[:])
+}
+
+// Store is a set of named secrets kept in a single file, encrypted and
+// authenticated with NaCl secretbox. The file holds the nonce followed by
+// the sealed JSON object of names to values.
+type Store struct {
+	secrets map[string]string
+	key     *Key
+	path    string
+}
+
+// Open decrypts the store at path. A missing file opens as an empty store,
+// created on the first Save.
+func Open(path string, key *Key) (*Store, error) {
+	store := &Store{path: path, key: key, secrets: map[string]string{}}
+
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(contents) < nonceSize {
+		return nil, ErrCorruptStore
+	}
+	var nonce [nonceSize]byte
+	copy(nonce[:], contents)
+	plaintext, ok := secretbox.Open(nil, contents[nonceSize:], &nonce, (*[keySize]byte)(key))
+	if !ok {
+		return nil, ErrCorruptStore
+	}
+	if err := json.Unmarshal(plaintext, &store.secrets); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptStore, err)
+	}
+	return store, nil
+}
+
+func (s *Store) Get(name string) (string, error) {
+	value, ok := s.secrets[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	return value, nil
+}
+
+func (s *Store) Set(name, value string) {
+	s.secrets[name] = value
+}
+
+func (s *Store) Delete(name string) error {
+	if _, ok := s.secrets[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	delete(s.secrets, name)
+	return nil
+}
+
+func (s *Store) Names() []string {
+	names := make([]string, 0, len(s.secrets))
+	for name := range s.secrets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Save encrypts the store with a fresh nonce and replaces the file
+// atomically, so that a failed write never leaves a truncated store.
+func (s *Store) Save() error {
+	plaintext, err := json.Marshal(s.secrets)
+	if err != nil {
+		return err
+	}
+
+	var nonce [nonceSize]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return err
+	}
+	sealed := secretbox.Seal(nonce[:], plaintext, &nonce, (*[keySize]byte)(s.key))
+
+	file, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(sealed); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), s.path)
+}
+
+// Resolve returns the secret named by a secret:// reference.
+func (s *Store) Resolve(reference string) (string, error) {
+	name, ok := strings.CutPrefix(reference, Scheme)
+	if !ok || name == "" {
+		return "", fmt.Errorf("%w: %s", ErrInvalidReference, reference)
+	}
+	return s.Get(name)
+}
+
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, Scheme)
+}
+
+var (
+	ErrInvalidKey       = errors.New("invalid secrets key")
+	ErrCorruptStore     = errors.New("secrets store cannot be decrypted")
+	ErrSecretNotFound   = errors.New("secret not found")
+	ErrInvalidReference = errors.New("invalid secret reference")
+)
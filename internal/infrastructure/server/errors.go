@@ -0,0 +1,145 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/dto"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+)
+
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+type resolvedError struct {
+	Code       string
+	Message    string
+	Field      string
+	Violations []dto.FieldViolation
+	StatusCode int
+}
+
+// newErrorHandler returns the echo error handler. Errors masked as internal
+// are logged with their cause; the others are expected outcomes, logged at
+// debug level only.
+func newErrorHandler(logger *slog.Logger) echo.HTTPErrorHandler {
+	return func(err error, ctx echo.Context) {
+		if ctx.Response().Committed {
+			return
+		}
+
+		req := ctx.Request()
+		resolved := resolveError(err)
+		level := slog.LevelDebug
+		if resolved.StatusCode == http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		logger.Log(req.Context(), level, "request failed",
+			slog.String("method", req.Method),
+			slog.String("path", req.URL.Path),
+			slog.String("code", resolved.Code),
+			slog.Any("error", err),
+		)
+
+		requestID := logging.RequestIDFromContext(req.Context())
+		if wantsLegacyError(req) {
+			ctx.JSON(resolved.StatusCode, &dto.ErrorResponse{
+				StatusCode: resolved.StatusCode,
+				Code:       resolved.Code,
+				Message:    resolved.Message,
+				Field:      resolved.Field,
+				RequestID:  requestID,
+			})
+			return
+		}
+
+		problem := newProblemDetails(resolved, req)
+		problem.RequestID = requestID
+		ctx.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+		ctx.JSON(resolved.StatusCode, problem)
+	}
+}
+
+func resolveError(err error) *resolvedError {
+	// Handlers and middleware wrap read errors in their own errors, but a
+	// body cut off by the body limit is the client's fault whoever read it.
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		err = api.ErrPayloadTooLarge
+	}
+
+	if mapping, ok := api.LookupError(err); ok {
+		violations := api.FieldViolations(err)
+		if len(violations) == 0 && mapping.Field != "" {
+			violations = []dto.FieldViolation{{Field: mapping.Field, Code: mapping.Code}}
+		}
+		return &resolvedError{
+			StatusCode: mapping.StatusCode,
+			Code:       mapping.Code,
+			Message:    mapping.Message,
+			Field:      mapping.Field,
+			Violations: violations,
+		}
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) && httpErr.Code < http.StatusInternalServerError {
+		return &resolvedError{
+			StatusCode: httpErr.Code,
+			Code:       errorCode(httpErr.Code),
+			Message:    fmt.Sprint(httpErr.Message),
+		}
+	}
+
+	return &resolvedError{
+		StatusCode: http.StatusInternalServerError,
+		Code:       errorCode(http.StatusInternalServerError),
+		Message:    "internal server error",
+	}
+}
+
+func newProblemDetails(resolved *resolvedError, req *http.Request) *dto.ProblemDetails {
+	return &dto.ProblemDetails{
+		Type:     "/problems/" + resolved.Code,
+		Title:    http.StatusText(resolved.StatusCode),
+		Status:   resolved.StatusCode,
+		Detail:   resolved.Message,
+		Instance: req.URL.Path,
+		Code:     resolved.Code,
+		Errors:   resolved.Violations,
+	}
+}
+
+// Clients that ask for plain JSON without mentioning problem+json predate
+// RFC 9457 responses and keep receiving dto.ErrorResponse.
+func wantsLegacyError(req *http.Request) bool {
+	accept := req.Header.Get(echo.HeaderAccept)
+	if strings.Contains(accept, MIMEApplicationProblemJSON) {
+		return false
+	}
+	return strings.Contains(accept, echo.MIMEApplicationJSON)
+}
+
+var errorCodes = map[int]string{
+	http.StatusBadRequest:            "bad_request",
+	http.StatusUnauthorized:          "unauthorized",
+	http.StatusForbidden:             "forbidden",
+	http.StatusNotFound:              "not_found",
+	http.StatusMethodNotAllowed:      "method_not_allowed",
+	http.StatusConflict:              "conflict",
+	http.StatusRequestEntityTooLarge: "payload_too_large",
+	http.StatusUnsupportedMediaType:  "unsupported_media_type",
+	http.StatusTooManyRequests:       "too_many_requests",
+	http.StatusInternalServerError:   "internal_error",
+}
+
+func errorCode(statusCode int) string {
+	if code, ok := errorCodes[statusCode]; ok {
+		return code
+	}
+	return "error"
+}
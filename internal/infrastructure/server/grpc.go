@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/grpcapi"
+	"google.golang.org/grpc"
+)
+
+type GRPCServer struct {
+	*grpc.Server
+}
+
+// NewGRPCServer chains panic recovery, request IDs, logging, API key
+// authentication and error conversion, in that order, around every unary
+// call.
+func NewGRPCServer(logger *slog.Logger, apiKeys []string, opts ...grpc.ServerOption) *GRPCServer {
+	opts = append(opts, grpc.ChainUnaryInterceptor(
+		grpcapi.RecoveryInterceptor(logger),
+		grpcapi.RequestIDInterceptor(),
+		grpcapi.LoggingInterceptor(logger),
+		grpcapi.APIKeyInterceptor(apiKeys),
+		grpcapi.ErrorInterceptor(logger),
+	))
+	return &GRPCServer{
+		Server: grpc.NewServer(opts...),
+	}
+}
+
+func (s *GRPCServer) Start(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	return s.Server.Serve(listener)
+}
+
+// Shutdown waits for in-flight calls to finish and closes every connection
+// once ctx is done.
+func (s *GRPCServer) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.Server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.Server.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,61 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net"
+	"testing"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/grpcapi"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/server"
+	"github.com/raphael-foliveira/go-table-tests/pkg/mocks"
+	usersv1 "github.com/raphael-foliveira/go-table-tests/pkg/proto/users/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGRPCServer_PanicRecovery(t *testing.T) {
+	var logs bytes.Buffer
+	mockUsersService := mocks.NewMockUsersService(t)
+	mockUsersService.EXPECT().
+		Login(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(context.Context, string, string) { panic("argon2: number of rounds too small") })
+
+	grpcServer := server.NewGRPCServer(logging.New(&logs, logging.FormatJSON, slog.LevelInfo), []string{"test-api-key"})
+	usersv1.RegisterUsersServiceServer(grpcServer, grpcapi.NewUsersServer(mockUsersService))
+
+	listener := bufconn.Listen(1024 * 1024)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	client := usersv1.NewUsersServiceClient(conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer test-api-key")
+
+	_, err = client.Login(ctx, &usersv1.LoginRequest{Email: "valid@user.com", Password: "valid_password"})
+	st := status.Convert(err)
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.NotContains(t, st.Message(), "argon2")
+	assert.Contains(t, logs.String(), `"msg":"handler panicked"`)
+	assert.Contains(t, logs.String(), `"stack":`)
+
+	// The server keeps serving after the panic.
+	_, err = client.Login(context.Background(), &usersv1.LoginRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
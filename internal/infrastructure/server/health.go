@@ -0,0 +1,158 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/dto"
+)
+
+const (
+	LivenessPath  = "/healthz"
+	ReadinessPath = "/readyz"
+
+	DefaultCheckTimeout = 2 * time.Second
+
+	StatusOK       = "ok"
+	StatusDown     = "down"
+	StatusDraining = "draining"
+
+	// The probes are public, so a failing component only reports one of
+	// these reasons; the error itself is logged.
+	ReasonCheckFailed = "check failed"
+	ReasonTimeout     = "check timed out"
+)
+
+// Checker is a dependency readiness depends on. Check is called with a
+// context bounded by the registry timeout.
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+type checkerFunc struct {
+	check func(ctx context.Context) error
+	name  string
+}
+
+// NewChecker adapts check into a Checker reported under name.
+func NewChecker(name string, check func(ctx context.Context) error) Checker {
+	return checkerFunc{name: name, check: check}
+}
+
+func (c checkerFunc) Name() string {
+	return c.name
+}
+
+func (c checkerFunc) Check(ctx context.Context) error {
+	return c.check(ctx)
+}
+
+// Health serves the liveness and readiness probes. Readiness runs every
+// registered checker concurrently and turns unavailable as soon as Drain is
+// called, so load balancers stop routing before the server shuts down.
+type Health struct {
+	logger   *slog.Logger
+	checkers []Checker
+	timeout  time.Duration
+	mu       sync.RWMutex
+	draining atomic.Bool
+}
+
+func NewHealth(timeout time.Duration, logger *slog.Logger) *Health {
+	return &Health{timeout: timeout, logger: logger}
+}
+
+func (h *Health) Register(checkers ...Checker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checkers = append(h.checkers, checkers...)
+}
+
+// Drain marks the application as not ready. It cannot be undone.
+func (h *Health) Drain() {
+	h.draining.Store(true)
+}
+
+func (h *Health) SetupRoutes(app *echo.Echo) {
+	app.GET(LivenessPath, h.Live)
+	app.GET(ReadinessPath, h.Ready)
+}
+
+func (h *Health) Live(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, dto.HealthResponse{Status: StatusOK})
+}
+
+func (h *Health) Ready(ctx echo.Context) error {
+	if h.draining.Load() {
+		return ctx.JSON(http.StatusServiceUnavailable, dto.HealthResponse{Status: StatusDraining})
+	}
+
+	response := h.check(ctx.Request().Context())
+	if response.Status != StatusOK {
+		return ctx.JSON(http.StatusServiceUnavailable, response)
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (h *Health) check(ctx context.Context) dto.HealthResponse {
+	h.mu.RLock()
+	checkers := h.checkers
+	h.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	results := make([]dto.ComponentHealth, len(checkers))
+	var wg sync.WaitGroup
+	for i, checker := range checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = h.runCheck(ctx, checker)
+		}()
+	}
+	wg.Wait()
+
+	response := dto.HealthResponse{
+		Status:     StatusOK,
+		Components: make(map[string]dto.ComponentHealth, len(checkers)),
+	}
+	for i, checker := range checkers {
+		if results[i].Status != StatusOK {
+			response.Status = StatusDown
+		}
+		response.Components[checker.Name()] = results[i]
+	}
+	return response
+}
+
+// runCheck gives up on a checker that ignores its context once the
+// deadline passes, so a hung dependency cannot stall the probe.
+func (h *Health) runCheck(ctx context.Context, checker Checker) dto.ComponentHealth {
+	done := make(chan error, 1)
+	go func() { done <- checker.Check(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if err == nil {
+		return dto.ComponentHealth{Status: StatusOK}
+	}
+
+	h.logger.WarnContext(ctx, "readiness check failed", "component", checker.Name(), "error", err)
+	reason := ReasonCheckFailed
+	if errors.Is(err, context.DeadlineExceeded) {
+		reason = ReasonTimeout
+	}
+	return dto.ComponentHealth{Status: StatusDown, Error: reason}
+}
@@ -0,0 +1,186 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/dto"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/lifecycle"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func healthy(context.Context) error {
+	return nil
+}
+
+func hung(ctx context.Context) error {
+	time.Sleep(time.Second)
+	return nil
+}
+
+func TestHealth_Ready(t *testing.T) {
+	tests := []struct {
+		expectedComponents map[string]dto.ComponentHealth
+		name               string
+		expectedStatus     string
+		checkers           []server.Checker
+		drain              bool
+		expectedStatusCode int
+	}{
+		{
+			name:               "No checkers",
+			expectedStatusCode: http.StatusOK,
+			expectedStatus:     server.StatusOK,
+		},
+		{
+			name: "All checkers healthy",
+			checkers: []server.Checker{
+				server.NewChecker("database", healthy),
+				server.NewChecker("migrations", healthy),
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedStatus:     server.StatusOK,
+			expectedComponents: map[string]dto.ComponentHealth{
+				"database":   {Status: server.StatusOK},
+				"migrations": {Status: server.StatusOK},
+			},
+		},
+		{
+			name: "One checker failing",
+			checkers: []server.Checker{
+				server.NewChecker("database", healthy),
+				server.NewChecker("migrations", func(context.Context) error {
+					return errors.New("pending migrations: 0004")
+				}),
+			},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedStatus:     server.StatusDown,
+			expectedComponents: map[string]dto.ComponentHealth{
+				"database":   {Status: server.StatusOK},
+				"migrations": {Status: server.StatusDown, Error: server.ReasonCheckFailed},
+			},
+		},
+		{
+			name: "Checker exceeding the timeout",
+			checkers: []server.Checker{
+				server.NewChecker("database", hung),
+			},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedStatus:     server.StatusDown,
+			expectedComponents: map[string]dto.ComponentHealth{
+				"database": {Status: server.StatusDown, Error: server.ReasonTimeout},
+			},
+		},
+		{
+			name: "Draining",
+			checkers: []server.Checker{
+				server.NewChecker("database", healthy),
+			},
+			drain:              true,
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedStatus:     server.StatusDraining,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := server.CreateApp(logging.Discard(), server.DefaultSecurityOptions())
+			health := server.NewHealth(50*time.Millisecond, logging.Discard())
+			health.Register(tt.checkers...)
+			health.SetupRoutes(app)
+			if tt.drain {
+				health.Drain()
+			}
+
+			recorder := httptest.NewRecorder()
+			app.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, server.ReadinessPath, nil))
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			var response dto.HealthResponse
+			require.NoError(t, json.NewDecoder(recorder.Body).Decode(&response))
+			assert.Equal(t, tt.expectedStatus, response.Status)
+			if tt.expectedComponents != nil {
+				assert.Equal(t, tt.expectedComponents, response.Components)
+			}
+		})
+	}
+}
+
+func TestHealth_Live(t *testing.T) {
+	app := server.CreateApp(logging.Discard(), server.DefaultSecurityOptions())
+	health := server.NewHealth(server.DefaultCheckTimeout, logging.Discard())
+	health.Register(server.NewChecker("database", func(context.Context) error {
+		return errors.New("connection refused")
+	}))
+	health.SetupRoutes(app)
+	health.Drain()
+
+	recorder := httptest.NewRecorder()
+	app.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, server.LivenessPath, nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, recorder.Body.String())
+}
+
+func TestHealth_DrainDelay(t *testing.T) {
+	app := server.CreateApp(logging.Discard(), server.DefaultSecurityOptions())
+	health := server.NewHealth(server.DefaultCheckTimeout, logging.Discard())
+	health.SetupRoutes(app)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	app.Listener = listener
+	baseURL := "http://" + listener.Addr().String()
+	// Idle keep-alive connections would hold up app.Shutdown.
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+
+	// The shutdown timeout leaves plenty of room after the drain delay,
+	// which only has to outlast the requests made while draining.
+	drained := make(chan struct{})
+	manager := lifecycle.New(logging.Discard(), 30*time.Second, 500*time.Millisecond)
+	manager.OnDrain(health.Drain)
+	manager.OnDrain(func() { close(drained) })
+	manager.Add("http", func() error {
+		if err := app.Start(""); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}, app.Shutdown)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- manager.Run(ctx) }()
+	require.Eventually(t, func() bool {
+		response, err := client.Get(baseURL + server.LivenessPath)
+		if err != nil {
+			return false
+		}
+		response.Body.Close()
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-drained
+
+	response, err := client.Get(baseURL + server.ReadinessPath)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+
+	response, err = client.Get(baseURL + server.LivenessPath)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	require.NoError(t, <-done)
+	_, err = client.Get(baseURL + server.LivenessPath)
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+)
+
+// requestID attaches the client's X-Request-ID, or a generated one, to the
+// request context and echoes it in the response. It runs before routing so
+// that unmatched routes get one too.
+func requestID() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			req := ctx.Request()
+			id := logging.ResolveRequestID(req.Header.Get(logging.RequestIDHeader))
+
+			ctx.SetRequest(req.WithContext(logging.ContextWithRequestID(req.Context(), id)))
+			ctx.Response().Header().Set(logging.RequestIDHeader, id)
+			return next(ctx)
+		}
+	}
+}
+
+// requestLogger logs the method, path, status and duration of every request.
+// It hands errors to the error handler itself so that the logged status is
+// the one sent.
+func requestLogger(logger *slog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			start := time.Now()
+			if err := next(ctx); err != nil {
+				ctx.Error(err)
+			}
+
+			req := ctx.Request()
+			logger.InfoContext(req.Context(), "request",
+				slog.String("method", req.Method),
+				slog.String("path", req.URL.Path),
+				slog.Int("status", ctx.Response().Status),
+				slog.Duration("duration", time.Since(start)),
+			)
+			return nil
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+)
+
+// SecurityOptions configures the hardening CreateApp applies to every
+// route. Overrides replace parts of it for individual routes.
+type SecurityOptions struct {
+	// Overrides is keyed by route path as registered, such as "/api/docs".
+	Overrides map[string]RouteSecurity
+	Headers   HeaderOptions
+	CORS      CORSOptions
+	Timeouts  Timeouts
+	// BodyLimit is the maximum request body size in bytes.
+	BodyLimit int64
+}
+
+// RouteSecurity overrides the options of a single route. Zero fields keep
+// the application-wide value.
+type RouteSecurity struct {
+	CORS                  *CORSOptions
+	ContentSecurityPolicy string
+	FrameOptions          string
+	BodyLimit             int64
+}
+
+type HeaderOptions struct {
+	ContentSecurityPolicy string
+	FrameOptions          string
+	ReferrerPolicy        string
+	// HSTSMaxAge is sent in Strict-Transport-Security on HTTPS requests
+	// only; zero disables the header.
+	HSTSMaxAge time.Duration
+}
+
+// CORSOptions allows cross-origin requests from AllowedOrigins only. An
+// empty list, the default, disables CORS.
+type CORSOptions struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	MaxAge           time.Duration
+	AllowCredentials bool
+}
+
+type Timeouts struct {
+	ReadHeader time.Duration
+	Read       time.Duration
+	Write      time.Duration
+	Idle       time.Duration
+}
+
+func DefaultSecurityOptions() SecurityOptions {
+	return SecurityOptions{
+		Headers: HeaderOptions{
+			ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'",
+			FrameOptions:          "DENY",
+			ReferrerPolicy:        "no-referrer",
+			HSTSMaxAge:            2 * 365 * 24 * time.Hour,
+		},
+		CORS: CORSOptions{
+			AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
+			AllowedHeaders: []string{echo.HeaderContentType, echo.HeaderAuthorization, api.CSRFHeader, logging.RequestIDHeader},
+			ExposedHeaders: []string{logging.RequestIDHeader},
+			MaxAge:         10 * time.Minute,
+		},
+		Timeouts: Timeouts{
+			ReadHeader: 5 * time.Second,
+			Read:       10 * time.Second,
+			Write:      30 * time.Second,
+			Idle:       2 * time.Minute,
+		},
+		BodyLimit: 1 << 20,
+	}
+}
+
+func (o SecurityOptions) route(path string) (CORSOptions, HeaderOptions, int64) {
+	cors, headers, bodyLimit := o.CORS, o.Headers, o.BodyLimit
+	override, ok := o.Overrides[path]
+	if !ok {
+		return cors, headers, bodyLimit
+	}
+	if override.CORS != nil {
+		cors = *override.CORS
+	}
+	if override.ContentSecurityPolicy != "" {
+		headers.ContentSecurityPolicy = override.ContentSecurityPolicy
+	}
+	if override.FrameOptions != "" {
+		headers.FrameOptions = override.FrameOptions
+	}
+	if override.BodyLimit != 0 {
+		bodyLimit = override.BodyLimit
+	}
+	return cors, headers, bodyLimit
+}
+
+// recoverer turns a panic in a handler into an error, so that it goes
+// through the error handler like any other internal error.
+func recoverer(logger *slog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					if r == http.ErrAbortHandler {
+						panic(r)
+					}
+					logger.ErrorContext(ctx.Request().Context(), "handler panicked",
+						slog.Any("panic", r),
+						slog.String("stack", string(debug.Stack())),
+					)
+					err = fmt.Errorf("%w: %v", ErrHandlerPanicked, r)
+				}
+			}()
+			return next(ctx)
+		}
+	}
+}
+
+// secure applies the CORS policy, the security headers and the body limit
+// of the matched route.
+func secure(options SecurityOptions) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			cors, headers, bodyLimit := options.route(ctx.Path())
+			req := ctx.Request()
+
+			setSecurityHeaders(ctx, headers)
+			if preflight := applyCORS(ctx, cors); preflight {
+				return ctx.NoContent(http.StatusNoContent)
+			}
+
+			if bodyLimit > 0 {
+				if req.ContentLength > bodyLimit {
+					return api.ErrPayloadTooLarge
+				}
+				req.Body = http.MaxBytesReader(ctx.Response(), req.Body, bodyLimit)
+			}
+			return next(ctx)
+		}
+	}
+}
+
+func setSecurityHeaders(ctx echo.Context, options HeaderOptions) {
+	header := ctx.Response().Header()
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+	if options.ContentSecurityPolicy != "" {
+		header.Set(echo.HeaderContentSecurityPolicy, options.ContentSecurityPolicy)
+	}
+	if options.FrameOptions != "" {
+		header.Set(echo.HeaderXFrameOptions, options.FrameOptions)
+	}
+	if options.ReferrerPolicy != "" {
+		header.Set(echo.HeaderReferrerPolicy, options.ReferrerPolicy)
+	}
+	if options.HSTSMaxAge > 0 && ctx.IsTLS() {
+		header.Set(echo.HeaderStrictTransportSecurity,
+			fmt.Sprintf("max-age=%d; includeSubDomains", int(options.HSTSMaxAge.Seconds())))
+	}
+}
+
+// applyCORS sets the CORS headers for allowed origins and reports whether
+// the request is a preflight, which is answered without reaching the
+// handler. Disallowed origins get no CORS headers, so browsers block them.
+func applyCORS(ctx echo.Context, options CORSOptions) bool {
+	req := ctx.Request()
+	origin := req.Header.Get(echo.HeaderOrigin)
+	preflight := req.Method == http.MethodOptions && req.Header.Get(echo.HeaderAccessControlRequestMethod) != ""
+	if origin == "" {
+		return false
+	}
+
+	header := ctx.Response().Header()
+	header.Add(echo.HeaderVary, echo.HeaderOrigin)
+	if !slices.Contains(options.AllowedOrigins, origin) {
+		return preflight
+	}
+
+	header.Set(echo.HeaderAccessControlAllowOrigin, origin)
+	if options.AllowCredentials {
+		header.Set(echo.HeaderAccessControlAllowCredentials, "true")
+	}
+	if !preflight {
+		if len(options.ExposedHeaders) > 0 {
+			header.Set(echo.HeaderAccessControlExposeHeaders, strings.Join(options.ExposedHeaders, ", "))
+		}
+		return false
+	}
+
+	header.Set(echo.HeaderAccessControlAllowMethods, strings.Join(options.AllowedMethods, ", "))
+	header.Set(echo.HeaderAccessControlAllowHeaders, strings.Join(options.AllowedHeaders, ", "))
+	if options.MaxAge > 0 {
+		header.Set(echo.HeaderAccessControlMaxAge, strconv.Itoa(int(options.MaxAge.Seconds())))
+	}
+	return true
+}
+
+var ErrHandlerPanicked = errors.New("handler panicked")
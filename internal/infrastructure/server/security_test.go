@@ -0,0 +1,245 @@
+package server_test
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raphael-foliveira/go-table-tests/internal/adapters/dto"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setUpSecureApp(t *testing.T, logs io.Writer) *echo.Echo {
+	options := server.DefaultSecurityOptions()
+	options.BodyLimit = 16
+	options.CORS.AllowedOrigins = []string{"https://app.example.com"}
+	options.Overrides = map[string]server.RouteSecurity{
+		"/docs":   {ContentSecurityPolicy: "default-src 'self'"},
+		"/upload": {BodyLimit: 1024},
+	}
+
+	app := server.CreateApp(logging.New(logs, logging.FormatJSON, slog.LevelInfo), options)
+	echoBody := func(ctx echo.Context) error {
+		body, err := io.ReadAll(ctx.Request().Body)
+		if err != nil {
+			return err
+		}
+		return ctx.String(http.StatusOK, string(body))
+	}
+	app.GET("/users", func(ctx echo.Context) error { return ctx.String(http.StatusOK, "users") })
+	app.GET("/docs", func(ctx echo.Context) error { return ctx.String(http.StatusOK, "docs") })
+	app.POST("/users", echoBody)
+	app.POST("/upload", echoBody)
+	app.GET("/panic", func(echo.Context) error { panic("nil map") })
+	return app
+}
+
+func TestServer_SecurityHeaders(t *testing.T) {
+	tests := []struct {
+		expectedHeaders map[string]string
+		name            string
+		path            string
+		tls             bool
+	}{
+		{
+			name: "Secure defaults",
+			path: "/users",
+			expectedHeaders: map[string]string{
+				"X-Content-Type-Options":    "nosniff",
+				"Content-Security-Policy":   "default-src 'none'; frame-ancestors 'none'",
+				"X-Frame-Options":           "DENY",
+				"Referrer-Policy":           "no-referrer",
+				"Strict-Transport-Security": "",
+			},
+		},
+		{
+			name: "HSTS over TLS",
+			path: "/users",
+			tls:  true,
+			expectedHeaders: map[string]string{
+				"Strict-Transport-Security": "max-age=63072000; includeSubDomains",
+			},
+		},
+		{
+			name: "Route override",
+			path: "/docs",
+			expectedHeaders: map[string]string{
+				"Content-Security-Policy": "default-src 'self'",
+				"X-Frame-Options":         "DENY",
+			},
+		},
+		{
+			name: "Unmatched route",
+			path: "/missing",
+			expectedHeaders: map[string]string{
+				"X-Content-Type-Options": "nosniff",
+				"X-Frame-Options":        "DENY",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setUpSecureApp(t, io.Discard)
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.tls {
+				req.TLS = &tls.ConnectionState{}
+			}
+			recorder := httptest.NewRecorder()
+
+			app.ServeHTTP(recorder, req)
+
+			for header, expected := range tt.expectedHeaders {
+				assert.Equal(t, expected, recorder.Header().Get(header), header)
+			}
+		})
+	}
+}
+
+func TestServer_CORS(t *testing.T) {
+	tests := []struct {
+		name                string
+		method              string
+		origin              string
+		expectedAllowOrigin string
+		expectedMethods     string
+		expectedStatusCode  int
+		preflight           bool
+	}{
+		{
+			name:                "Allowed origin",
+			method:              http.MethodGet,
+			origin:              "https://app.example.com",
+			expectedStatusCode:  http.StatusOK,
+			expectedAllowOrigin: "https://app.example.com",
+		},
+		{
+			name:               "Disallowed origin",
+			method:             http.MethodGet,
+			origin:             "https://evil.example.com",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:                "Allowed preflight",
+			method:              http.MethodOptions,
+			origin:              "https://app.example.com",
+			preflight:           true,
+			expectedStatusCode:  http.StatusNoContent,
+			expectedAllowOrigin: "https://app.example.com",
+			expectedMethods:     "GET, POST, PUT, PATCH, DELETE",
+		},
+		{
+			name:               "Disallowed preflight",
+			method:             http.MethodOptions,
+			origin:             "https://evil.example.com",
+			preflight:          true,
+			expectedStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setUpSecureApp(t, io.Discard)
+			req := httptest.NewRequest(tt.method, "/users", nil)
+			req.Header.Set("Origin", tt.origin)
+			if tt.preflight {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+			recorder := httptest.NewRecorder()
+
+			app.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			assert.Equal(t, tt.expectedAllowOrigin, recorder.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tt.expectedMethods, recorder.Header().Get("Access-Control-Allow-Methods"))
+			assert.Equal(t, "Origin", recorder.Header().Get("Vary"))
+		})
+	}
+}
+
+func TestServer_BodyLimit(t *testing.T) {
+	tests := []struct {
+		name               string
+		path               string
+		body               string
+		expectedCode       string
+		expectedStatusCode int
+		chunked            bool
+	}{
+		{
+			name:               "Within limit",
+			path:               "/users",
+			body:               `{"a": 1}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Declared length over limit",
+			path:               "/users",
+			body:               strings.Repeat("a", 17),
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+			expectedCode:       "payload_too_large",
+		},
+		{
+			name:               "Streamed body over limit",
+			path:               "/users",
+			body:               strings.Repeat("a", 17),
+			chunked:            true,
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+			expectedCode:       "payload_too_large",
+		},
+		{
+			name:               "Route override",
+			path:               "/upload",
+			body:               strings.Repeat("a", 512),
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setUpSecureApp(t, io.Discard)
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			recorder := httptest.NewRecorder()
+
+			app.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expectedStatusCode, recorder.Code)
+			if tt.expectedCode != "" {
+				var problem dto.ProblemDetails
+				require.NoError(t, json.NewDecoder(recorder.Body).Decode(&problem))
+				assert.Equal(t, tt.expectedCode, problem.Code)
+			}
+		})
+	}
+}
+
+func TestServer_PanicRecovery(t *testing.T) {
+	var logs bytes.Buffer
+	app := setUpSecureApp(t, &logs)
+	recorder := httptest.NewRecorder()
+
+	app.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "nil map")
+	var problem dto.ProblemDetails
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&problem))
+	assert.Equal(t, "internal_error", problem.Code)
+	assert.NotEmpty(t, problem.RequestID)
+
+	assert.Contains(t, logs.String(), `"msg":"handler panicked"`)
+	assert.Contains(t, logs.String(), `"status":500`)
+}
@@ -1,17 +1,23 @@
 package server
 
 import (
-	"errors"
-	"fmt"
-	"net/http"
+	"crypto/tls"
+	"log/slog"
 
 	"github.com/labstack/echo/v4"
-	"github.com/raphael-foliveira/go-table-tests/internal/adapters/dto"
 )
 
-func CreateApp() *echo.Echo {
+func CreateApp(logger *slog.Logger, security SecurityOptions) *echo.Echo {
 	app := echo.New()
-	app.HTTPErrorHandler = customErrorHandler
+	app.HTTPErrorHandler = newErrorHandler(logger)
+	app.Server.ReadHeaderTimeout = security.Timeouts.ReadHeader
+	app.Server.ReadTimeout = security.Timeouts.Read
+	app.Server.WriteTimeout = security.Timeouts.Write
+	app.Server.IdleTimeout = security.Timeouts.Idle
+	app.Pre(requestID())
+	app.Use(requestLogger(logger))
+	app.Use(recoverer(logger))
+	app.Use(secure(security))
 	return app
 }
 
@@ -19,27 +25,15 @@ type Server struct {
 	*echo.Echo
 }
 
-func NewServer() *Server {
+func NewServer(logger *slog.Logger, security SecurityOptions) *Server {
 	return &Server{
-		Echo: CreateApp(),
+		Echo: CreateApp(logger, security),
 	}
 }
 
-func (s *Server) Start(port int) error {
-	addr := fmt.Sprintf(":%d", port)
-	return s.Echo.Start(addr)
-}
-
-func customErrorHandler(err error, ctx echo.Context) {
-	res := &dto.ErrorResponse{
-		StatusCode: http.StatusInternalServerError,
-		Message:    "internal server error",
-	}
-	var httpErr *echo.HTTPError
-	if errors.As(err, &httpErr) {
-		res.StatusCode = httpErr.Code
-		res.Message = httpErr.Message.(string)
-	}
-
-	ctx.JSON(res.StatusCode, res)
+// Start serves HTTP on address, or HTTPS when tlsConfig is not nil.
+func (s *Server) Start(address string, tlsConfig *tls.Config) error {
+	s.Echo.Server.Addr = address
+	s.Echo.Server.TLSConfig = tlsConfig
+	return s.Echo.StartServer(s.Echo.Server)
 }
@@ -1,7 +1,12 @@
 package server_test
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -9,20 +14,26 @@ import (
 
 	"github.com/raphael-foliveira/go-table-tests/internal/adapters/api"
 	"github.com/raphael-foliveira/go-table-tests/internal/adapters/dto"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/service"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
 	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/server"
 	"github.com/raphael-foliveira/go-table-tests/pkg/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestServer_ErrorHandler(t *testing.T) {
-	app := server.CreateApp()
+	app := server.CreateApp(logging.Discard(), server.DefaultSecurityOptions())
 	apiGroup := app.Group("/api")
 	mockUsersService := mocks.NewMockUsersService(t)
-	usersHandler := api.NewUsersHandler(mockUsersService)
+	usersHandler := api.NewUsersHandler(mockUsersService, api.NewSessions(mocks.NewMockTokenService(t), api.DefaultSessionOptions()))
 
 	usersHandler.SetupRoutes(apiGroup)
 
 	req := httptest.NewRequest("POST", "/api/users/login", strings.NewReader(`{"invalid_field": "invalid_value"}`))
+	req.Header.Add("Accept", "application/json")
 	recorder := httptest.NewRecorder()
 
 	app.Server.Handler.ServeHTTP(recorder, req)
@@ -37,3 +48,295 @@ func TestServer_ErrorHandler(t *testing.T) {
 	assert.Equal(t, api.ErrInvalidPayload.Message, responseBody.Message)
 	assert.Equal(t, http.StatusBadRequest, responseBody.StatusCode)
 }
+
+func TestServer_ErrorHandlerMapping(t *testing.T) {
+	tests := []struct {
+		serviceError       error
+		name               string
+		expectedMessage    string
+		expectedCode       string
+		expectedField      string
+		expectedStatusCode int
+	}{
+		{
+			name:               "Email already taken",
+			serviceError:       service.ErrEmailAlreadyTaken,
+			expectedStatusCode: http.StatusConflict,
+			expectedCode:       "email_taken",
+			expectedMessage:    "email already taken",
+			expectedField:      "email",
+		},
+		{
+			name:               "Username already taken",
+			serviceError:       service.ErrUsernameAlreadyTaken,
+			expectedStatusCode: http.StatusConflict,
+			expectedCode:       "username_taken",
+			expectedMessage:    "username already taken",
+			expectedField:      "username",
+		},
+		{
+			name:               "Password too short",
+			serviceError:       fmt.Errorf("%w: %w", service.ErrInvalidUserPayload, domain.ErrPasswordTooShort),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedCode:       "password_too_short",
+			expectedMessage:    "password is too short",
+			expectedField:      "password",
+		},
+		{
+			name:               "Invalid email",
+			serviceError:       fmt.Errorf("%w: %w", service.ErrInvalidUserPayload, domain.ErrEmailInvalid),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedCode:       "email_invalid",
+			expectedMessage:    "email is not valid",
+			expectedField:      "email",
+		},
+		{
+			name:               "Unknown error is masked",
+			serviceError:       errors.New("connection refused"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedCode:       "internal_error",
+			expectedMessage:    "internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := server.CreateApp(logging.Discard(), server.DefaultSecurityOptions())
+			apiGroup := app.Group("/api")
+			mockUsersService := mocks.NewMockUsersService(t)
+			usersHandler := api.NewUsersHandler(mockUsersService, api.NewSessions(mocks.NewMockTokenService(t), api.DefaultSessionOptions()))
+
+			usersHandler.SetupRoutes(apiGroup)
+
+			mockUsersService.EXPECT().
+				Signup(mock.Anything, mock.Anything).
+				Return(nil, tt.serviceError)
+
+			req := httptest.NewRequest("POST", "/api/users/signup", strings.NewReader(`{"username": "testuser", "email": "test@user.com", "password": "unhashedPassword"}`))
+			req.Header.Add("Content-Type", "application/json")
+			req.Header.Add("Accept", "application/json")
+			recorder := httptest.NewRecorder()
+
+			app.Server.Handler.ServeHTTP(recorder, req)
+
+			response := recorder.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatusCode, response.StatusCode)
+			var responseBody *dto.ErrorResponse
+
+			json.NewDecoder(response.Body).Decode(&responseBody)
+			assert.Equal(t, tt.expectedMessage, responseBody.Message)
+			assert.Equal(t, tt.expectedCode, responseBody.Code)
+			assert.Equal(t, tt.expectedField, responseBody.Field)
+			assert.Equal(t, tt.expectedStatusCode, responseBody.StatusCode)
+		})
+	}
+}
+
+func TestServer_ErrorHandlerProblemDetails(t *testing.T) {
+	tests := []struct {
+		serviceError       error
+		name               string
+		accept             string
+		expectedCode       string
+		expectedErrors     []dto.FieldViolation
+		expectedStatusCode int
+	}{
+		{
+			name:               "Validation error",
+			serviceError:       fmt.Errorf("%w: %w", service.ErrInvalidUserPayload, domain.ErrPasswordTooShort),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedCode:       "password_too_short",
+			expectedErrors: []dto.FieldViolation{
+				{Field: "password", Code: "too_short", Detail: "password is too short"},
+			},
+		},
+		{
+			name: "Several validation errors",
+			serviceError: fmt.Errorf("%w: %w", service.ErrInvalidUserPayload, domain.ValidationErrors{
+				domain.ErrEmailInvalid,
+				domain.ErrPasswordTooShort,
+			}),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedCode:       "validation_failed",
+			expectedErrors: []dto.FieldViolation{
+				{Field: "email", Code: "invalid", Detail: "email is not valid"},
+				{Field: "password", Code: "too_short", Detail: "password is too short"},
+			},
+		},
+		{
+			name:               "Conflict",
+			accept:             "application/problem+json, application/json",
+			serviceError:       service.ErrEmailAlreadyTaken,
+			expectedStatusCode: http.StatusConflict,
+			expectedCode:       "email_taken",
+			expectedErrors: []dto.FieldViolation{
+				{Field: "email", Code: "email_taken"},
+			},
+		},
+		{
+			name:               "Unknown error",
+			accept:             "*/*",
+			serviceError:       errors.New("connection refused"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedCode:       "internal_error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := server.CreateApp(logging.Discard(), server.DefaultSecurityOptions())
+			apiGroup := app.Group("/api")
+			mockUsersService := mocks.NewMockUsersService(t)
+			usersHandler := api.NewUsersHandler(mockUsersService, api.NewSessions(mocks.NewMockTokenService(t), api.DefaultSessionOptions()))
+
+			usersHandler.SetupRoutes(apiGroup)
+
+			mockUsersService.EXPECT().
+				Signup(mock.Anything, mock.Anything).
+				Return(nil, tt.serviceError)
+
+			req := httptest.NewRequest("POST", "/api/users/signup", strings.NewReader(`{"username": "testuser", "email": "test@user.com", "password": "unhashedPassword"}`))
+			req.Header.Add("Content-Type", "application/json")
+			if tt.accept != "" {
+				req.Header.Add("Accept", tt.accept)
+			}
+			recorder := httptest.NewRecorder()
+
+			app.Server.Handler.ServeHTTP(recorder, req)
+
+			response := recorder.Result()
+			defer response.Body.Close()
+
+			assert.Equal(t, tt.expectedStatusCode, response.StatusCode)
+			assert.Equal(t, server.MIMEApplicationProblemJSON, response.Header.Get("Content-Type"))
+
+			var responseBody *dto.ProblemDetails
+			json.NewDecoder(response.Body).Decode(&responseBody)
+			assert.Equal(t, "/problems/"+tt.expectedCode, responseBody.Type)
+			assert.Equal(t, http.StatusText(tt.expectedStatusCode), responseBody.Title)
+			assert.Equal(t, tt.expectedStatusCode, responseBody.Status)
+			assert.Equal(t, tt.expectedCode, responseBody.Code)
+			assert.Equal(t, "/api/users/signup", responseBody.Instance)
+			assert.Equal(t, tt.expectedErrors, responseBody.Errors)
+		})
+	}
+}
+
+func TestServer_RequestValidation(t *testing.T) {
+	app := server.CreateApp(logging.Discard(), server.DefaultSecurityOptions())
+	mockUsersService := mocks.NewMockUsersService(t)
+	usersHandler := api.NewUsersHandler(mockUsersService, api.NewSessions(mocks.NewMockTokenService(t), api.DefaultSessionOptions()))
+	docsHandler := api.NewDocsHandler(usersHandler)
+
+	apiGroup := app.Group(api.APIBaseURL)
+	apiGroup.Use(api.ValidateRequests(docsHandler.Document(), api.APIBaseURL))
+	usersHandler.SetupRoutes(apiGroup)
+
+	req := httptest.NewRequest("POST", "/api/users/login", strings.NewReader(`{"invalid_field": "invalid_value"}`))
+	req.Header.Add("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	app.Server.Handler.ServeHTTP(recorder, req)
+
+	response := recorder.Result()
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	var responseBody *dto.ProblemDetails
+	json.NewDecoder(response.Body).Decode(&responseBody)
+	assert.Equal(t, "invalid_payload", responseBody.Code)
+	assert.Equal(t, []dto.FieldViolation{
+		{Field: "email", Code: "required", Detail: "is required"},
+		{Field: "password", Code: "required", Detail: "is required"},
+		{Field: "invalid_field", Code: "unknown_field", Detail: "is not a known field"},
+	}, responseBody.Errors)
+}
+
+func TestServer_RequestID(t *testing.T) {
+	tests := []struct {
+		name            string
+		requestID       string
+		accept          string
+		expectGenerated bool
+	}{
+		{
+			name:      "Propagates the client's ID",
+			requestID: "client-request-id",
+		},
+		{
+			name:            "Generates a missing ID",
+			expectGenerated: true,
+		},
+		{
+			name:            "Replaces an unsafe ID",
+			requestID:       "forged id",
+			expectGenerated: true,
+		},
+		{
+			name:      "Legacy error response",
+			requestID: "client-request-id",
+			accept:    "application/json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			app := server.CreateApp(logging.New(&logs, logging.FormatJSON, slog.LevelInfo), server.DefaultSecurityOptions())
+			apiGroup := app.Group("/api")
+			mockUsersService := mocks.NewMockUsersService(t)
+			api.NewUsersHandler(mockUsersService, api.NewSessions(mocks.NewMockTokenService(t), api.DefaultSessionOptions())).SetupRoutes(apiGroup)
+
+			var seen string
+			mockUsersService.EXPECT().
+				Signup(mock.Anything, mock.Anything).
+				RunAndReturn(func(ctx context.Context, _ *domain.SignupPayload) (*domain.SignupResponse, error) {
+					seen = logging.RequestIDFromContext(ctx)
+					return nil, errors.New("connection refused")
+				})
+
+			req := httptest.NewRequest("POST", "/api/users/signup", strings.NewReader(`{"username": "testuser", "email": "test@user.com", "password": "unhashedPassword"}`))
+			req.Header.Add("Content-Type", "application/json")
+			if tt.requestID != "" {
+				req.Header.Add("X-Request-ID", tt.requestID)
+			}
+			if tt.accept != "" {
+				req.Header.Add("Accept", tt.accept)
+			}
+			recorder := httptest.NewRecorder()
+
+			app.Server.Handler.ServeHTTP(recorder, req)
+
+			response := recorder.Result()
+			defer response.Body.Close()
+
+			require.NotEmpty(t, seen)
+			if tt.expectGenerated {
+				assert.NotEqual(t, tt.requestID, seen)
+			} else {
+				assert.Equal(t, tt.requestID, seen)
+			}
+			assert.Equal(t, seen, response.Header.Get("X-Request-ID"))
+
+			var responseBody struct {
+				RequestID string `json:"request_id"`
+			}
+			json.NewDecoder(response.Body).Decode(&responseBody)
+			assert.Equal(t, seen, responseBody.RequestID)
+
+			// Both the error and the access log line carry the ID.
+			lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+			require.Len(t, lines, 2)
+			for _, line := range lines {
+				var record map[string]any
+				require.NoError(t, json.Unmarshal([]byte(line), &record))
+				assert.Equal(t, seen, record[logging.RequestIDKey])
+			}
+			assert.Contains(t, lines[0], "connection refused")
+			assert.Contains(t, lines[1], `"status":500`)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/labstack/echo/v4"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+)
+
+// certificateReloadDebounce coalesces the events of a certificate renewal,
+// which usually rewrites the certificate and the key one after the other.
+const certificateReloadDebounce = 100 * time.Millisecond
+
+type TLSOptions struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile enables optional mutual TLS: clients may present a
+	// certificate, which must then be signed by one of its certificate
+	// authorities. Clients without one connect as before.
+	ClientCAFile string
+	CipherSuites []uint16
+	MinVersion   uint16
+}
+
+// TLS serves the certificate from disk and swaps it whenever Reload
+// succeeds, so that renewals apply without a restart.
+type TLS struct {
+	certificate atomic.Pointer[tls.Certificate]
+	clientCAs   *x509.CertPool
+	logger      *slog.Logger
+	options     TLSOptions
+}
+
+func NewTLS(options TLSOptions, logger *slog.Logger) (*TLS, error) {
+	t := &TLS{options: options, logger: logger}
+	if err := t.Reload(); err != nil {
+		return nil, err
+	}
+
+	if options.ClientCAFile != "" {
+		contents, err := os.ReadFile(options.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		t.clientCAs = x509.NewCertPool()
+		if !t.clientCAs.AppendCertsFromPEM(contents) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidClientCA, options.ClientCAFile)
+		}
+	}
+	return t, nil
+}
+
+func (t *TLS) Config() *tls.Config {
+	config := &tls.Config{
+		MinVersion:   t.options.MinVersion,
+		CipherSuites: t.options.CipherSuites,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return t.certificate.Load(), nil
+		},
+	}
+	if t.clientCAs != nil {
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+		config.ClientCAs = t.clientCAs
+	}
+	return config
+}
+
+// Reload reads the certificate and key again. On error the certificate
+// being served stays in place.
+func (t *TLS) Reload() error {
+	certificate, err := tls.LoadX509KeyPair(t.options.CertFile, t.options.KeyFile)
+	if err != nil {
+		return err
+	}
+	t.certificate.Store(&certificate)
+	return nil
+}
+
+// Watch reloads the certificate whenever the directory of the certificate
+// or of the key changes, until ctx is done.
+func (t *TLS) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	for _, dir := range []string{filepath.Dir(t.options.CertFile), filepath.Dir(t.options.KeyFile)} {
+		if err := watcher.Add(dir); err != nil {
+			return err
+		}
+	}
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-watcher.Events:
+			if !event.Has(fsnotify.Chmod) {
+				debounce = time.After(certificateReloadDebounce)
+			}
+		case <-debounce:
+			debounce = nil
+			if err := t.Reload(); err != nil {
+				t.logger.Error("certificate reload failed, keeping the current certificate", "error", err)
+				continue
+			}
+			t.logger.Info("certificate reloaded")
+		case err := <-watcher.Errors:
+			t.logger.Warn("certificate watch failed", "error", err)
+		}
+	}
+}
+
+// ClientCertificatePrincipal attaches the identity of a verified client
+// certificate to the request context as the principal. Identities listed in
+// adminIdentities get the admin role, the others the user role.
+func ClientCertificatePrincipal(adminIdentities []string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			req := ctx.Request()
+			if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 {
+				return next(ctx)
+			}
+
+			identity := CertificateIdentity(req.TLS.VerifiedChains[0][0])
+			principal := &domain.Principal{Role: domain.RoleUser, Subject: identity}
+			if slices.Contains(adminIdentities, identity) {
+				principal.Role = domain.RoleAdmin
+			}
+			ctx.SetRequest(req.WithContext(domain.ContextWithPrincipal(req.Context(), principal)))
+			return next(ctx)
+		}
+	}
+}
+
+// CertificateIdentity is the first URI SAN of certificate, as SPIFFE IDs
+// are, falling back to the first DNS SAN and then to the common name.
+func CertificateIdentity(certificate *x509.Certificate) string {
+	if len(certificate.URIs) > 0 {
+		return certificate.URIs[0].String()
+	}
+	if len(certificate.DNSNames) > 0 {
+		return certificate.DNSNames[0]
+	}
+	return certificate.Subject.CommonName
+}
+
+// ParseTLSVersion accepts "1.2" and "1.3"; older versions are not offered.
+func ParseTLSVersion(value string) (uint16, error) {
+	switch value {
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidTLSVersion, value)
+}
+
+// ParseCipherSuites accepts the names of the secure suites crypto/tls
+// implements. They only apply to TLS 1.2; TLS 1.3 suites are not
+// configurable.
+func ParseCipherSuites(names []string) ([]uint16, error) {
+	suites := make([]uint16, 0, len(names))
+	for _, name := range names {
+		index := slices.IndexFunc(tls.CipherSuites(), func(suite *tls.CipherSuite) bool {
+			return suite.Name == name
+		})
+		if index < 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidCipherSuite, name)
+		}
+		suites = append(suites, tls.CipherSuites()[index].ID)
+	}
+	return suites, nil
+}
+
+var (
+	ErrInvalidClientCA    = errors.New("no certificate authority found in client CA file")
+	ErrInvalidTLSVersion  = errors.New("invalid TLS version")
+	ErrInvalidCipherSuite = errors.New("invalid cipher suite")
+)
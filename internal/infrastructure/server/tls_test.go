@@ -0,0 +1,292 @@
+package server_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/logging"
+	"github.com/raphael-foliveira/go-table-tests/internal/infrastructure/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	certPEM     []byte
+	keyPEM      []byte
+}
+
+func issueCertificate(t *testing.T, template *x509.Certificate, parent *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template.SerialNumber = serial
+	template.NotBefore = time.Now().Add(-time.Minute)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.certificate, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return &testCertificate{
+		certificate: certificate,
+		key:         key,
+		certPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:      pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func issueServerCertificate(t *testing.T, ca *testCertificate) *testCertificate {
+	return issueCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "users"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca)
+}
+
+func issueClientCertificate(t *testing.T, ca *testCertificate, identity string) tls.Certificate {
+	identityURL, err := url.Parse(identity)
+	require.NoError(t, err)
+	client := issueCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "client"},
+		URIs:        []*url.URL{identityURL},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca)
+	certificate, err := tls.X509KeyPair(client.certPEM, client.keyPEM)
+	require.NoError(t, err)
+	return certificate
+}
+
+func writeCertificate(t *testing.T, certificate *testCertificate, certFile, keyFile string) {
+	require.NoError(t, os.WriteFile(keyFile, certificate.keyPEM, 0o600))
+	require.NoError(t, os.WriteFile(certFile, certificate.certPEM, 0o600))
+}
+
+func TestServer_StartTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, caFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "ca.crt")
+
+	ca := issueCertificate(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "test CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+	require.NoError(t, os.WriteFile(caFile, ca.certPEM, 0o600))
+	original := issueServerCertificate(t, ca)
+	writeCertificate(t, original, certFile, keyFile)
+
+	serverTLS, err := server.NewTLS(server.TLSOptions{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		ClientCAFile: caFile,
+		MinVersion:   tls.VersionTLS12,
+	}, logging.Discard())
+	require.NoError(t, err)
+
+	app := server.NewServer(logging.Discard(), server.DefaultSecurityOptions())
+	app.HideBanner, app.HidePort = true, true
+	app.Use(server.ClientCertificatePrincipal([]string{"spiffe://internal/billing"}))
+	app.GET("/whoami", func(ctx echo.Context) error {
+		principal, ok := domain.PrincipalFromContext(ctx.Request().Context())
+		if !ok {
+			return ctx.String(http.StatusOK, "anonymous")
+		}
+		return ctx.String(http.StatusOK, fmt.Sprintf("%s %s", principal.Subject, principal.Role))
+	})
+
+	started := make(chan error, 1)
+	go func() { started <- app.Start("127.0.0.1:0", serverTLS.Config()) }()
+	t.Cleanup(func() {
+		app.Shutdown(context.Background())
+		assert.ErrorIs(t, <-started, http.ErrServerClosed)
+	})
+	require.Eventually(t, func() bool { return app.TLSListenerAddr() != nil }, 5*time.Second, 10*time.Millisecond)
+	address := "https://" + app.TLSListenerAddr().String() + "/whoami"
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.certificate)
+	request := func(certificates ...tls.Certificate) (*http.Response, error) {
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{RootCAs: roots, Certificates: certificates},
+			DisableKeepAlives: true,
+		}}
+		return client.Get(address)
+	}
+
+	tests := []struct {
+		name         string
+		identity     string
+		expectedBody string
+	}{
+		{name: "Admin client", identity: "spiffe://internal/billing", expectedBody: "spiffe://internal/billing admin"},
+		{name: "Other client", identity: "spiffe://internal/reports", expectedBody: "spiffe://internal/reports user"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := request(issueClientCertificate(t, ca, tt.identity))
+			require.NoError(t, err)
+			defer response.Body.Close()
+
+			body := make([]byte, 64)
+			n, _ := response.Body.Read(body)
+			assert.Equal(t, tt.expectedBody, string(body[:n]))
+			assert.Equal(t, original.certificate.SerialNumber, response.TLS.PeerCertificates[0].SerialNumber)
+		})
+	}
+
+	t.Run("Client without certificate", func(t *testing.T) {
+		response, err := request()
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "anonymous", string(body))
+	})
+
+	t.Run("Client with an untrusted certificate", func(t *testing.T) {
+		untrusted := issueCertificate(t, &x509.Certificate{
+			Subject:               pkix.Name{CommonName: "untrusted CA"},
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}, nil)
+
+		certificate := issueClientCertificate(t, untrusted, "spiffe://internal/billing")
+		// Go clients only offer certificates the server's CAs signed, so
+		// this one has to be forced on the handshake.
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: roots,
+				GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+					return &certificate, nil
+				},
+			},
+			DisableKeepAlives: true,
+		}}
+
+		_, err := client.Get(address)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Certificate reloaded when the files change", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		watching := make(chan error, 1)
+		go func() { watching <- serverTLS.Watch(ctx) }()
+		defer func() {
+			cancel()
+			assert.NoError(t, <-watching)
+		}()
+
+		renewed := issueServerCertificate(t, ca)
+		client := issueClientCertificate(t, ca, "spiffe://internal/billing")
+		require.Eventually(t, func() bool {
+			writeCertificate(t, renewed, certFile, keyFile)
+			response, err := request(client)
+			if err != nil {
+				return false
+			}
+			response.Body.Close()
+			return response.TLS.PeerCertificates[0].SerialNumber.Cmp(renewed.certificate.SerialNumber) == 0
+		}, 5*time.Second, 300*time.Millisecond, "the writes must be further apart than the reload debounce")
+	})
+}
+
+func TestTLS_ReloadKeepsCertificateOnError(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	ca := issueCertificate(t, &x509.Certificate{IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign}, nil)
+	writeCertificate(t, issueServerCertificate(t, ca), certFile, keyFile)
+
+	serverTLS, err := server.NewTLS(server.TLSOptions{CertFile: certFile, KeyFile: keyFile}, logging.Discard())
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(certFile, []byte("not a certificate"), 0o600))
+
+	assert.Error(t, serverTLS.Reload())
+	certificate, err := serverTLS.Config().GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.NotNil(t, certificate)
+}
+
+func TestParseTLSOptions(t *testing.T) {
+	tests := []struct {
+		expectedErr          error
+		name                 string
+		version              string
+		cipherSuites         []string
+		expectedVersion      uint16
+		expectedCipherSuites []uint16
+	}{
+		{
+			name:                 "TLS 1.2 with suites",
+			version:              "1.2",
+			cipherSuites:         []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+			expectedVersion:      tls.VersionTLS12,
+			expectedCipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+		},
+		{
+			name:                 "TLS 1.3",
+			version:              "1.3",
+			expectedVersion:      tls.VersionTLS13,
+			expectedCipherSuites: []uint16{},
+		},
+		{
+			name:        "Outdated version",
+			version:     "1.0",
+			expectedErr: server.ErrInvalidTLSVersion,
+		},
+		{
+			name:         "Insecure suite",
+			version:      "1.2",
+			cipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"},
+			expectedErr:  server.ErrInvalidCipherSuite,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, versionErr := server.ParseTLSVersion(tt.version)
+			cipherSuites, suitesErr := server.ParseCipherSuites(tt.cipherSuites)
+
+			err := errors.Join(versionErr, suitesErr)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedVersion, version)
+			assert.Equal(t, tt.expectedCipherSuites, cipherSuites)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockInviter is an autogenerated mock type for the Inviter type
+type MockInviter struct {
+	mock.Mock
+}
+
+type MockInviter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockInviter) EXPECT() *MockInviter_Expecter {
+	return &MockInviter_Expecter{mock: &_m.Mock}
+}
+
+// Invite provides a mock function with given fields: ctx, user
+func (_m *MockInviter) Invite(ctx context.Context, user *domain.UserProfile) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Invite")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.UserProfile) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockInviter_Invite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Invite'
+type MockInviter_Invite_Call struct {
+	*mock.Call
+}
+
+// Invite is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *domain.UserProfile
+func (_e *MockInviter_Expecter) Invite(ctx interface{}, user interface{}) *MockInviter_Invite_Call {
+	return &MockInviter_Invite_Call{Call: _e.mock.On("Invite", ctx, user)}
+}
+
+func (_c *MockInviter_Invite_Call) Run(run func(ctx context.Context, user *domain.UserProfile)) *MockInviter_Invite_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.UserProfile))
+	})
+	return _c
+}
+
+func (_c *MockInviter_Invite_Call) Return(_a0 error) *MockInviter_Invite_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockInviter_Invite_Call) RunAndReturn(run func(context.Context, *domain.UserProfile) error) *MockInviter_Invite_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockInviter creates a new instance of MockInviter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInviter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInviter {
+	mock := &MockInviter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,136 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockMetrics is an autogenerated mock type for the Metrics type
+type MockMetrics struct {
+	mock.Mock
+}
+
+type MockMetrics_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMetrics) EXPECT() *MockMetrics_Expecter {
+	return &MockMetrics_Expecter{mock: &_m.Mock}
+}
+
+// ObserveHashing provides a mock function with given fields: operation, duration
+func (_m *MockMetrics) ObserveHashing(operation string, duration time.Duration) {
+	_m.Called(operation, duration)
+}
+
+// MockMetrics_ObserveHashing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ObserveHashing'
+type MockMetrics_ObserveHashing_Call struct {
+	*mock.Call
+}
+
+// ObserveHashing is a helper method to define mock.On call
+//   - operation string
+//   - duration time.Duration
+func (_e *MockMetrics_Expecter) ObserveHashing(operation interface{}, duration interface{}) *MockMetrics_ObserveHashing_Call {
+	return &MockMetrics_ObserveHashing_Call{Call: _e.mock.On("ObserveHashing", operation, duration)}
+}
+
+func (_c *MockMetrics_ObserveHashing_Call) Run(run func(operation string, duration time.Duration)) *MockMetrics_ObserveHashing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockMetrics_ObserveHashing_Call) Return() *MockMetrics_ObserveHashing_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMetrics_ObserveHashing_Call) RunAndReturn(run func(string, time.Duration)) *MockMetrics_ObserveHashing_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RecordLogin provides a mock function with given fields: outcome
+func (_m *MockMetrics) RecordLogin(outcome string) {
+	_m.Called(outcome)
+}
+
+// MockMetrics_RecordLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordLogin'
+type MockMetrics_RecordLogin_Call struct {
+	*mock.Call
+}
+
+// RecordLogin is a helper method to define mock.On call
+//   - outcome string
+func (_e *MockMetrics_Expecter) RecordLogin(outcome interface{}) *MockMetrics_RecordLogin_Call {
+	return &MockMetrics_RecordLogin_Call{Call: _e.mock.On("RecordLogin", outcome)}
+}
+
+func (_c *MockMetrics_RecordLogin_Call) Run(run func(outcome string)) *MockMetrics_RecordLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockMetrics_RecordLogin_Call) Return() *MockMetrics_RecordLogin_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMetrics_RecordLogin_Call) RunAndReturn(run func(string)) *MockMetrics_RecordLogin_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RecordSignup provides a mock function with given fields: outcome
+func (_m *MockMetrics) RecordSignup(outcome string) {
+	_m.Called(outcome)
+}
+
+// MockMetrics_RecordSignup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordSignup'
+type MockMetrics_RecordSignup_Call struct {
+	*mock.Call
+}
+
+// RecordSignup is a helper method to define mock.On call
+//   - outcome string
+func (_e *MockMetrics_Expecter) RecordSignup(outcome interface{}) *MockMetrics_RecordSignup_Call {
+	return &MockMetrics_RecordSignup_Call{Call: _e.mock.On("RecordSignup", outcome)}
+}
+
+func (_c *MockMetrics_RecordSignup_Call) Run(run func(outcome string)) *MockMetrics_RecordSignup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockMetrics_RecordSignup_Call) Return() *MockMetrics_RecordSignup_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMetrics_RecordSignup_Call) RunAndReturn(run func(string)) *MockMetrics_RecordSignup_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockMetrics creates a new instance of MockMetrics. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMetrics(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMetrics {
+	mock := &MockMetrics{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
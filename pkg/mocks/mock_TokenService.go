@@ -0,0 +1,151 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTokenService is an autogenerated mock type for the TokenService type
+type MockTokenService struct {
+	mock.Mock
+}
+
+type MockTokenService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTokenService) EXPECT() *MockTokenService_Expecter {
+	return &MockTokenService_Expecter{mock: &_m.Mock}
+}
+
+// Issue provides a mock function with given fields: principal
+func (_m *MockTokenService) Issue(principal *domain.Principal) (*domain.AccessToken, error) {
+	ret := _m.Called(principal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Issue")
+	}
+
+	var r0 *domain.AccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.Principal) (*domain.AccessToken, error)); ok {
+		return rf(principal)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.Principal) *domain.AccessToken); ok {
+		r0 = rf(principal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.Principal) error); ok {
+		r1 = rf(principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTokenService_Issue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Issue'
+type MockTokenService_Issue_Call struct {
+	*mock.Call
+}
+
+// Issue is a helper method to define mock.On call
+//   - principal *domain.Principal
+func (_e *MockTokenService_Expecter) Issue(principal interface{}) *MockTokenService_Issue_Call {
+	return &MockTokenService_Issue_Call{Call: _e.mock.On("Issue", principal)}
+}
+
+func (_c *MockTokenService_Issue_Call) Run(run func(principal *domain.Principal)) *MockTokenService_Issue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*domain.Principal))
+	})
+	return _c
+}
+
+func (_c *MockTokenService_Issue_Call) Return(_a0 *domain.AccessToken, _a1 error) *MockTokenService_Issue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTokenService_Issue_Call) RunAndReturn(run func(*domain.Principal) (*domain.AccessToken, error)) *MockTokenService_Issue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function with given fields: token
+func (_m *MockTokenService) Verify(token string) (*domain.Principal, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 *domain.Principal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.Principal, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.Principal); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Principal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTokenService_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type MockTokenService_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - token string
+func (_e *MockTokenService_Expecter) Verify(token interface{}) *MockTokenService_Verify_Call {
+	return &MockTokenService_Verify_Call{Call: _e.mock.On("Verify", token)}
+}
+
+func (_c *MockTokenService_Verify_Call) Run(run func(token string)) *MockTokenService_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockTokenService_Verify_Call) Return(_a0 *domain.Principal, _a1 error) *MockTokenService_Verify_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTokenService_Verify_Call) RunAndReturn(run func(string) (*domain.Principal, error)) *MockTokenService_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTokenService creates a new instance of MockTokenService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTokenService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTokenService {
+	mock := &MockTokenService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,82 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	ports "github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTransaction is an autogenerated mock type for the Transaction type
+type MockTransaction struct {
+	mock.Mock
+}
+
+type MockTransaction_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTransaction) EXPECT() *MockTransaction_Expecter {
+	return &MockTransaction_Expecter{mock: &_m.Mock}
+}
+
+// Users provides a mock function with no fields
+func (_m *MockTransaction) Users() ports.UsersRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Users")
+	}
+
+	var r0 ports.UsersRepository
+	if rf, ok := ret.Get(0).(func() ports.UsersRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ports.UsersRepository)
+		}
+	}
+
+	return r0
+}
+
+// MockTransaction_Users_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Users'
+type MockTransaction_Users_Call struct {
+	*mock.Call
+}
+
+// Users is a helper method to define mock.On call
+func (_e *MockTransaction_Expecter) Users() *MockTransaction_Users_Call {
+	return &MockTransaction_Users_Call{Call: _e.mock.On("Users")}
+}
+
+func (_c *MockTransaction_Users_Call) Run(run func()) *MockTransaction_Users_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockTransaction_Users_Call) Return(_a0 ports.UsersRepository) *MockTransaction_Users_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTransaction_Users_Call) RunAndReturn(run func() ports.UsersRepository) *MockTransaction_Users_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTransaction creates a new instance of MockTransaction. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTransaction(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTransaction {
+	mock := &MockTransaction{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
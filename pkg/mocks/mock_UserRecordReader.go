@@ -0,0 +1,92 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockUserRecordReader is an autogenerated mock type for the UserRecordReader type
+type MockUserRecordReader struct {
+	mock.Mock
+}
+
+type MockUserRecordReader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUserRecordReader) EXPECT() *MockUserRecordReader_Expecter {
+	return &MockUserRecordReader_Expecter{mock: &_m.Mock}
+}
+
+// Read provides a mock function with no fields
+func (_m *MockUserRecordReader) Read() (*domain.ImportRecord, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Read")
+	}
+
+	var r0 *domain.ImportRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*domain.ImportRecord, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *domain.ImportRecord); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ImportRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRecordReader_Read_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Read'
+type MockUserRecordReader_Read_Call struct {
+	*mock.Call
+}
+
+// Read is a helper method to define mock.On call
+func (_e *MockUserRecordReader_Expecter) Read() *MockUserRecordReader_Read_Call {
+	return &MockUserRecordReader_Read_Call{Call: _e.mock.On("Read")}
+}
+
+func (_c *MockUserRecordReader_Read_Call) Run(run func()) *MockUserRecordReader_Read_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockUserRecordReader_Read_Call) Return(_a0 *domain.ImportRecord, _a1 error) *MockUserRecordReader_Read_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRecordReader_Read_Call) RunAndReturn(run func() (*domain.ImportRecord, error)) *MockUserRecordReader_Read_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUserRecordReader creates a new instance of MockUserRecordReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUserRecordReader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUserRecordReader {
+	mock := &MockUserRecordReader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,126 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockUserRecordWriter is an autogenerated mock type for the UserRecordWriter type
+type MockUserRecordWriter struct {
+	mock.Mock
+}
+
+type MockUserRecordWriter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUserRecordWriter) EXPECT() *MockUserRecordWriter_Expecter {
+	return &MockUserRecordWriter_Expecter{mock: &_m.Mock}
+}
+
+// Flush provides a mock function with no fields
+func (_m *MockUserRecordWriter) Flush() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Flush")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserRecordWriter_Flush_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Flush'
+type MockUserRecordWriter_Flush_Call struct {
+	*mock.Call
+}
+
+// Flush is a helper method to define mock.On call
+func (_e *MockUserRecordWriter_Expecter) Flush() *MockUserRecordWriter_Flush_Call {
+	return &MockUserRecordWriter_Flush_Call{Call: _e.mock.On("Flush")}
+}
+
+func (_c *MockUserRecordWriter_Flush_Call) Run(run func()) *MockUserRecordWriter_Flush_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockUserRecordWriter_Flush_Call) Return(_a0 error) *MockUserRecordWriter_Flush_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserRecordWriter_Flush_Call) RunAndReturn(run func() error) *MockUserRecordWriter_Flush_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Write provides a mock function with given fields: user
+func (_m *MockUserRecordWriter) Write(user *domain.UserProfile) error {
+	ret := _m.Called(user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Write")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.UserProfile) error); ok {
+		r0 = rf(user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserRecordWriter_Write_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Write'
+type MockUserRecordWriter_Write_Call struct {
+	*mock.Call
+}
+
+// Write is a helper method to define mock.On call
+//   - user *domain.UserProfile
+func (_e *MockUserRecordWriter_Expecter) Write(user interface{}) *MockUserRecordWriter_Write_Call {
+	return &MockUserRecordWriter_Write_Call{Call: _e.mock.On("Write", user)}
+}
+
+func (_c *MockUserRecordWriter_Write_Call) Run(run func(user *domain.UserProfile)) *MockUserRecordWriter_Write_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*domain.UserProfile))
+	})
+	return _c
+}
+
+func (_c *MockUserRecordWriter_Write_Call) Return(_a0 error) *MockUserRecordWriter_Write_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserRecordWriter_Write_Call) RunAndReturn(run func(*domain.UserProfile) error) *MockUserRecordWriter_Write_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUserRecordWriter creates a new instance of MockUserRecordWriter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUserRecordWriter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUserRecordWriter {
+	mock := &MockUserRecordWriter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,394 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockUsersAdminService is an autogenerated mock type for the UsersAdminService type
+type MockUsersAdminService struct {
+	mock.Mock
+}
+
+type MockUsersAdminService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUsersAdminService) EXPECT() *MockUsersAdminService_Expecter {
+	return &MockUsersAdminService_Expecter{mock: &_m.Mock}
+}
+
+// CreateUser provides a mock function with given fields: ctx, payload, role
+func (_m *MockUsersAdminService) CreateUser(ctx context.Context, payload *domain.SignupPayload, role domain.Role) (*domain.UserProfile, error) {
+	ret := _m.Called(ctx, payload, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 *domain.UserProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.SignupPayload, domain.Role) (*domain.UserProfile, error)); ok {
+		return rf(ctx, payload, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.SignupPayload, domain.Role) *domain.UserProfile); ok {
+		r0 = rf(ctx, payload, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.SignupPayload, domain.Role) error); ok {
+		r1 = rf(ctx, payload, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUsersAdminService_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type MockUsersAdminService_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payload *domain.SignupPayload
+//   - role domain.Role
+func (_e *MockUsersAdminService_Expecter) CreateUser(ctx interface{}, payload interface{}, role interface{}) *MockUsersAdminService_CreateUser_Call {
+	return &MockUsersAdminService_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, payload, role)}
+}
+
+func (_c *MockUsersAdminService_CreateUser_Call) Run(run func(ctx context.Context, payload *domain.SignupPayload, role domain.Role)) *MockUsersAdminService_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.SignupPayload), args[2].(domain.Role))
+	})
+	return _c
+}
+
+func (_c *MockUsersAdminService_CreateUser_Call) Return(_a0 *domain.UserProfile, _a1 error) *MockUsersAdminService_CreateUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUsersAdminService_CreateUser_Call) RunAndReturn(run func(context.Context, *domain.SignupPayload, domain.Role) (*domain.UserProfile, error)) *MockUsersAdminService_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DisableUser provides a mock function with given fields: ctx, identifier
+func (_m *MockUsersAdminService) DisableUser(ctx context.Context, identifier string) (*domain.UserProfile, error) {
+	ret := _m.Called(ctx, identifier)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableUser")
+	}
+
+	var r0 *domain.UserProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.UserProfile, error)); ok {
+		return rf(ctx, identifier)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.UserProfile); ok {
+		r0 = rf(ctx, identifier)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, identifier)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUsersAdminService_DisableUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableUser'
+type MockUsersAdminService_DisableUser_Call struct {
+	*mock.Call
+}
+
+// DisableUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - identifier string
+func (_e *MockUsersAdminService_Expecter) DisableUser(ctx interface{}, identifier interface{}) *MockUsersAdminService_DisableUser_Call {
+	return &MockUsersAdminService_DisableUser_Call{Call: _e.mock.On("DisableUser", ctx, identifier)}
+}
+
+func (_c *MockUsersAdminService_DisableUser_Call) Run(run func(ctx context.Context, identifier string)) *MockUsersAdminService_DisableUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockUsersAdminService_DisableUser_Call) Return(_a0 *domain.UserProfile, _a1 error) *MockUsersAdminService_DisableUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUsersAdminService_DisableUser_Call) RunAndReturn(run func(context.Context, string) (*domain.UserProfile, error)) *MockUsersAdminService_DisableUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function with given fields: ctx, identifier
+func (_m *MockUsersAdminService) GetUser(ctx context.Context, identifier string) (*domain.UserProfile, error) {
+	ret := _m.Called(ctx, identifier)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *domain.UserProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.UserProfile, error)); ok {
+		return rf(ctx, identifier)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.UserProfile); ok {
+		r0 = rf(ctx, identifier)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, identifier)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUsersAdminService_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockUsersAdminService_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - identifier string
+func (_e *MockUsersAdminService_Expecter) GetUser(ctx interface{}, identifier interface{}) *MockUsersAdminService_GetUser_Call {
+	return &MockUsersAdminService_GetUser_Call{Call: _e.mock.On("GetUser", ctx, identifier)}
+}
+
+func (_c *MockUsersAdminService_GetUser_Call) Run(run func(ctx context.Context, identifier string)) *MockUsersAdminService_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockUsersAdminService_GetUser_Call) Return(_a0 *domain.UserProfile, _a1 error) *MockUsersAdminService_GetUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUsersAdminService_GetUser_Call) RunAndReturn(run func(context.Context, string) (*domain.UserProfile, error)) *MockUsersAdminService_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, query
+func (_m *MockUsersAdminService) List(ctx context.Context, query domain.ListUsersQuery) (*domain.UsersPage, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *domain.UsersPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ListUsersQuery) (*domain.UsersPage, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ListUsersQuery) *domain.UsersPage); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UsersPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ListUsersQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUsersAdminService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockUsersAdminService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query domain.ListUsersQuery
+func (_e *MockUsersAdminService_Expecter) List(ctx interface{}, query interface{}) *MockUsersAdminService_List_Call {
+	return &MockUsersAdminService_List_Call{Call: _e.mock.On("List", ctx, query)}
+}
+
+func (_c *MockUsersAdminService_List_Call) Run(run func(ctx context.Context, query domain.ListUsersQuery)) *MockUsersAdminService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ListUsersQuery))
+	})
+	return _c
+}
+
+func (_c *MockUsersAdminService_List_Call) Return(_a0 *domain.UsersPage, _a1 error) *MockUsersAdminService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUsersAdminService_List_Call) RunAndReturn(run func(context.Context, domain.ListUsersQuery) (*domain.UsersPage, error)) *MockUsersAdminService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPassword provides a mock function with given fields: ctx, identifier, password
+func (_m *MockUsersAdminService) ResetPassword(ctx context.Context, identifier string, password string) (*domain.UserProfile, error) {
+	ret := _m.Called(ctx, identifier, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 *domain.UserProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.UserProfile, error)); ok {
+		return rf(ctx, identifier, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.UserProfile); ok {
+		r0 = rf(ctx, identifier, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, identifier, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUsersAdminService_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type MockUsersAdminService_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - identifier string
+//   - password string
+func (_e *MockUsersAdminService_Expecter) ResetPassword(ctx interface{}, identifier interface{}, password interface{}) *MockUsersAdminService_ResetPassword_Call {
+	return &MockUsersAdminService_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, identifier, password)}
+}
+
+func (_c *MockUsersAdminService_ResetPassword_Call) Run(run func(ctx context.Context, identifier string, password string)) *MockUsersAdminService_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockUsersAdminService_ResetPassword_Call) Return(_a0 *domain.UserProfile, _a1 error) *MockUsersAdminService_ResetPassword_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUsersAdminService_ResetPassword_Call) RunAndReturn(run func(context.Context, string, string) (*domain.UserProfile, error)) *MockUsersAdminService_ResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetRole provides a mock function with given fields: ctx, identifier, role
+func (_m *MockUsersAdminService) SetRole(ctx context.Context, identifier string, role domain.Role) (*domain.UserProfile, error) {
+	ret := _m.Called(ctx, identifier, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRole")
+	}
+
+	var r0 *domain.UserProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Role) (*domain.UserProfile, error)); ok {
+		return rf(ctx, identifier, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Role) *domain.UserProfile); ok {
+		r0 = rf(ctx, identifier, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.Role) error); ok {
+		r1 = rf(ctx, identifier, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUsersAdminService_SetRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRole'
+type MockUsersAdminService_SetRole_Call struct {
+	*mock.Call
+}
+
+// SetRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - identifier string
+//   - role domain.Role
+func (_e *MockUsersAdminService_Expecter) SetRole(ctx interface{}, identifier interface{}, role interface{}) *MockUsersAdminService_SetRole_Call {
+	return &MockUsersAdminService_SetRole_Call{Call: _e.mock.On("SetRole", ctx, identifier, role)}
+}
+
+func (_c *MockUsersAdminService_SetRole_Call) Run(run func(ctx context.Context, identifier string, role domain.Role)) *MockUsersAdminService_SetRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.Role))
+	})
+	return _c
+}
+
+func (_c *MockUsersAdminService_SetRole_Call) Return(_a0 *domain.UserProfile, _a1 error) *MockUsersAdminService_SetRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUsersAdminService_SetRole_Call) RunAndReturn(run func(context.Context, string, domain.Role) (*domain.UserProfile, error)) *MockUsersAdminService_SetRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUsersAdminService creates a new instance of MockUsersAdminService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUsersAdminService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUsersAdminService {
+	mock := &MockUsersAdminService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,155 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/raphael-foliveira/go-table-tests/internal/core/domain"
+	ports "github.com/raphael-foliveira/go-table-tests/internal/core/ports"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockUsersBulkService is an autogenerated mock type for the UsersBulkService type
+type MockUsersBulkService struct {
+	mock.Mock
+}
+
+type MockUsersBulkService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUsersBulkService) EXPECT() *MockUsersBulkService_Expecter {
+	return &MockUsersBulkService_Expecter{mock: &_m.Mock}
+}
+
+// Export provides a mock function with given fields: ctx, writer
+func (_m *MockUsersBulkService) Export(ctx context.Context, writer ports.UserRecordWriter) (int, error) {
+	ret := _m.Called(ctx, writer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ports.UserRecordWriter) (int, error)); ok {
+		return rf(ctx, writer)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ports.UserRecordWriter) int); ok {
+		r0 = rf(ctx, writer)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ports.UserRecordWriter) error); ok {
+		r1 = rf(ctx, writer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUsersBulkService_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type MockUsersBulkService_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - ctx context.Context
+//   - writer ports.UserRecordWriter
+func (_e *MockUsersBulkService_Expecter) Export(ctx interface{}, writer interface{}) *MockUsersBulkService_Export_Call {
+	return &MockUsersBulkService_Export_Call{Call: _e.mock.On("Export", ctx, writer)}
+}
+
+func (_c *MockUsersBulkService_Export_Call) Run(run func(ctx context.Context, writer ports.UserRecordWriter)) *MockUsersBulkService_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ports.UserRecordWriter))
+	})
+	return _c
+}
+
+func (_c *MockUsersBulkService_Export_Call) Return(_a0 int, _a1 error) *MockUsersBulkService_Export_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUsersBulkService_Export_Call) RunAndReturn(run func(context.Context, ports.UserRecordWriter) (int, error)) *MockUsersBulkService_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Import provides a mock function with given fields: ctx, reader, options
+func (_m *MockUsersBulkService) Import(ctx context.Context, reader ports.UserRecordReader, options domain.ImportOptions) (*domain.ImportReport, error) {
+	ret := _m.Called(ctx, reader, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 *domain.ImportReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ports.UserRecordReader, domain.ImportOptions) (*domain.ImportReport, error)); ok {
+		return rf(ctx, reader, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ports.UserRecordReader, domain.ImportOptions) *domain.ImportReport); ok {
+		r0 = rf(ctx, reader, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ImportReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ports.UserRecordReader, domain.ImportOptions) error); ok {
+		r1 = rf(ctx, reader, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUsersBulkService_Import_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Import'
+type MockUsersBulkService_Import_Call struct {
+	*mock.Call
+}
+
+// Import is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reader ports.UserRecordReader
+//   - options domain.ImportOptions
+func (_e *MockUsersBulkService_Expecter) Import(ctx interface{}, reader interface{}, options interface{}) *MockUsersBulkService_Import_Call {
+	return &MockUsersBulkService_Import_Call{Call: _e.mock.On("Import", ctx, reader, options)}
+}
+
+func (_c *MockUsersBulkService_Import_Call) Run(run func(ctx context.Context, reader ports.UserRecordReader, options domain.ImportOptions)) *MockUsersBulkService_Import_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ports.UserRecordReader), args[2].(domain.ImportOptions))
+	})
+	return _c
+}
+
+func (_c *MockUsersBulkService_Import_Call) Return(_a0 *domain.ImportReport, _a1 error) *MockUsersBulkService_Import_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUsersBulkService_Import_Call) RunAndReturn(run func(context.Context, ports.UserRecordReader, domain.ImportOptions) (*domain.ImportReport, error)) *MockUsersBulkService_Import_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUsersBulkService creates a new instance of MockUsersBulkService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUsersBulkService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUsersBulkService {
+	mock := &MockUsersBulkService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
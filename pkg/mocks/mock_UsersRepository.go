@@ -1,8 +1,10 @@
-// Code generated by mockery v2.43.0. DO NOT EDIT.
+// Code generated by mockery v2.53.3. DO NOT EDIT.
 
 package mocks
 
 import (
+	context "context"
+
 	domain "github.com/raphael-foliveira/go-table-tests/internal/core/domain"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -20,17 +22,17 @@ func (_m *MockUsersRepository) EXPECT() *MockUsersRepository_Expecter {
 	return &MockUsersRepository_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function with given fields: user
-func (_m *MockUsersRepository) Create(user *domain.User) error {
-	ret := _m.Called(user)
+// Create provides a mock function with given fields: ctx, user
+func (_m *MockUsersRepository) Create(ctx context.Context, user *domain.User) error {
+	ret := _m.Called(ctx, user)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*domain.User) error); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) error); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -44,14 +46,15 @@ type MockUsersRepository_Create_Call struct {
 }
 
 // Create is a helper method to define mock.On call
+//   - ctx context.Context
 //   - user *domain.User
-func (_e *MockUsersRepository_Expecter) Create(user interface{}) *MockUsersRepository_Create_Call {
-	return &MockUsersRepository_Create_Call{Call: _e.mock.On("Create", user)}
+func (_e *MockUsersRepository_Expecter) Create(ctx interface{}, user interface{}) *MockUsersRepository_Create_Call {
+	return &MockUsersRepository_Create_Call{Call: _e.mock.On("Create", ctx, user)}
 }
 
-func (_c *MockUsersRepository_Create_Call) Run(run func(user *domain.User)) *MockUsersRepository_Create_Call {
+func (_c *MockUsersRepository_Create_Call) Run(run func(ctx context.Context, user *domain.User)) *MockUsersRepository_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*domain.User))
+		run(args[0].(context.Context), args[1].(*domain.User))
 	})
 	return _c
 }
@@ -61,14 +64,61 @@ func (_c *MockUsersRepository_Create_Call) Return(_a0 error) *MockUsersRepositor
 	return _c
 }
 
-func (_c *MockUsersRepository_Create_Call) RunAndReturn(run func(*domain.User) error) *MockUsersRepository_Create_Call {
+func (_c *MockUsersRepository_Create_Call) RunAndReturn(run func(context.Context, *domain.User) error) *MockUsersRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBatch provides a mock function with given fields: ctx, users
+func (_m *MockUsersRepository) CreateBatch(ctx context.Context, users []*domain.User) error {
+	ret := _m.Called(ctx, users)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.User) error); ok {
+		r0 = rf(ctx, users)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUsersRepository_CreateBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBatch'
+type MockUsersRepository_CreateBatch_Call struct {
+	*mock.Call
+}
+
+// CreateBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - users []*domain.User
+func (_e *MockUsersRepository_Expecter) CreateBatch(ctx interface{}, users interface{}) *MockUsersRepository_CreateBatch_Call {
+	return &MockUsersRepository_CreateBatch_Call{Call: _e.mock.On("CreateBatch", ctx, users)}
+}
+
+func (_c *MockUsersRepository_CreateBatch_Call) Run(run func(ctx context.Context, users []*domain.User)) *MockUsersRepository_CreateBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*domain.User))
+	})
+	return _c
+}
+
+func (_c *MockUsersRepository_CreateBatch_Call) Return(_a0 error) *MockUsersRepository_CreateBatch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUsersRepository_CreateBatch_Call) RunAndReturn(run func(context.Context, []*domain.User) error) *MockUsersRepository_CreateBatch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindByEmail provides a mock function with given fields: email
-func (_m *MockUsersRepository) FindByEmail(email string) (*domain.User, error) {
-	ret := _m.Called(email)
+// FindByEmail provides a mock function with given fields: ctx, email
+func (_m *MockUsersRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	ret := _m.Called(ctx, email)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindByEmail")
@@ -76,19 +126,19 @@ func (_m *MockUsersRepository) FindByEmail(email string) (*domain.User, error) {
 
 	var r0 *domain.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*domain.User, error)); ok {
-		return rf(email)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return rf(ctx, email)
 	}
-	if rf, ok := ret.Get(0).(func(string) *domain.User); ok {
-		r0 = rf(email)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = rf(ctx, email)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(email)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -102,14 +152,15 @@ type MockUsersRepository_FindByEmail_Call struct {
 }
 
 // FindByEmail is a helper method to define mock.On call
+//   - ctx context.Context
 //   - email string
-func (_e *MockUsersRepository_Expecter) FindByEmail(email interface{}) *MockUsersRepository_FindByEmail_Call {
-	return &MockUsersRepository_FindByEmail_Call{Call: _e.mock.On("FindByEmail", email)}
+func (_e *MockUsersRepository_Expecter) FindByEmail(ctx interface{}, email interface{}) *MockUsersRepository_FindByEmail_Call {
+	return &MockUsersRepository_FindByEmail_Call{Call: _e.mock.On("FindByEmail", ctx, email)}
 }
 
-func (_c *MockUsersRepository_FindByEmail_Call) Run(run func(email string)) *MockUsersRepository_FindByEmail_Call {
+func (_c *MockUsersRepository_FindByEmail_Call) Run(run func(ctx context.Context, email string)) *MockUsersRepository_FindByEmail_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -119,14 +170,73 @@ func (_c *MockUsersRepository_FindByEmail_Call) Return(_a0 *domain.User, _a1 err
 	return _c
 }
 
-func (_c *MockUsersRepository_FindByEmail_Call) RunAndReturn(run func(string) (*domain.User, error)) *MockUsersRepository_FindByEmail_Call {
+func (_c *MockUsersRepository_FindByEmail_Call) RunAndReturn(run func(context.Context, string) (*domain.User, error)) *MockUsersRepository_FindByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *MockUsersRepository) FindByID(ctx context.Context, id uint) (*domain.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*domain.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *domain.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUsersRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockUsersRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+func (_e *MockUsersRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockUsersRepository_FindByID_Call {
+	return &MockUsersRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockUsersRepository_FindByID_Call) Run(run func(ctx context.Context, id uint)) *MockUsersRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockUsersRepository_FindByID_Call) Return(_a0 *domain.User, _a1 error) *MockUsersRepository_FindByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUsersRepository_FindByID_Call) RunAndReturn(run func(context.Context, uint) (*domain.User, error)) *MockUsersRepository_FindByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindByUsername provides a mock function with given fields: username
-func (_m *MockUsersRepository) FindByUsername(username string) (*domain.User, error) {
-	ret := _m.Called(username)
+// FindByUsername provides a mock function with given fields: ctx, username
+func (_m *MockUsersRepository) FindByUsername(ctx context.Context, username string) (*domain.User, error) {
+	ret := _m.Called(ctx, username)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindByUsername")
@@ -134,19 +244,19 @@ func (_m *MockUsersRepository) FindByUsername(username string) (*domain.User, er
 
 	var r0 *domain.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*domain.User, error)); ok {
-		return rf(username)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return rf(ctx, username)
 	}
-	if rf, ok := ret.Get(0).(func(string) *domain.User); ok {
-		r0 = rf(username)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = rf(ctx, username)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(username)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -160,14 +270,15 @@ type MockUsersRepository_FindByUsername_Call struct {
 }
 
 // FindByUsername is a helper method to define mock.On call
+//   - ctx context.Context
 //   - username string
-func (_e *MockUsersRepository_Expecter) FindByUsername(username interface{}) *MockUsersRepository_FindByUsername_Call {
-	return &MockUsersRepository_FindByUsername_Call{Call: _e.mock.On("FindByUsername", username)}
+func (_e *MockUsersRepository_Expecter) FindByUsername(ctx interface{}, username interface{}) *MockUsersRepository_FindByUsername_Call {
+	return &MockUsersRepository_FindByUsername_Call{Call: _e.mock.On("FindByUsername", ctx, username)}
 }
 
-func (_c *MockUsersRepository_FindByUsername_Call) Run(run func(username string)) *MockUsersRepository_FindByUsername_Call {
+func (_c *MockUsersRepository_FindByUsername_Call) Run(run func(ctx context.Context, username string)) *MockUsersRepository_FindByUsername_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -177,7 +288,114 @@ func (_c *MockUsersRepository_FindByUsername_Call) Return(_a0 *domain.User, _a1
 	return _c
 }
 
-func (_c *MockUsersRepository_FindByUsername_Call) RunAndReturn(run func(string) (*domain.User, error)) *MockUsersRepository_FindByUsername_Call {
+func (_c *MockUsersRepository_FindByUsername_Call) RunAndReturn(run func(context.Context, string) (*domain.User, error)) *MockUsersRepository_FindByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, after, limit
+func (_m *MockUsersRepository) List(ctx context.Context, after uint, limit int) ([]*domain.User, error) {
+	ret := _m.Called(ctx, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int) ([]*domain.User, error)); ok {
+		return rf(ctx, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int) []*domain.User); ok {
+		r0 = rf(ctx, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, int) error); ok {
+		r1 = rf(ctx, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUsersRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockUsersRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - after uint
+//   - limit int
+func (_e *MockUsersRepository_Expecter) List(ctx interface{}, after interface{}, limit interface{}) *MockUsersRepository_List_Call {
+	return &MockUsersRepository_List_Call{Call: _e.mock.On("List", ctx, after, limit)}
+}
+
+func (_c *MockUsersRepository_List_Call) Run(run func(ctx context.Context, after uint, limit int)) *MockUsersRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockUsersRepository_List_Call) Return(_a0 []*domain.User, _a1 error) *MockUsersRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUsersRepository_List_Call) RunAndReturn(run func(context.Context, uint, int) ([]*domain.User, error)) *MockUsersRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, user
+func (_m *MockUsersRepository) Update(ctx context.Context, user *domain.User) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUsersRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockUsersRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *domain.User
+func (_e *MockUsersRepository_Expecter) Update(ctx interface{}, user interface{}) *MockUsersRepository_Update_Call {
+	return &MockUsersRepository_Update_Call{Call: _e.mock.On("Update", ctx, user)}
+}
+
+func (_c *MockUsersRepository_Update_Call) Run(run func(ctx context.Context, user *domain.User)) *MockUsersRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.User))
+	})
+	return _c
+}
+
+func (_c *MockUsersRepository_Update_Call) Return(_a0 error) *MockUsersRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUsersRepository_Update_Call) RunAndReturn(run func(context.Context, *domain.User) error) *MockUsersRepository_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1,8 +1,10 @@
-// Code generated by mockery v2.43.0. DO NOT EDIT.
+// Code generated by mockery v2.53.3. DO NOT EDIT.
 
 package mocks
 
 import (
+	context "context"
+
 	domain "github.com/raphael-foliveira/go-table-tests/internal/core/domain"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -20,9 +22,68 @@ func (_m *MockUsersService) EXPECT() *MockUsersService_Expecter {
 	return &MockUsersService_Expecter{mock: &_m.Mock}
 }
 
-// Login provides a mock function with given fields: email, password
-func (_m *MockUsersService) Login(email string, password string) (*domain.LoginResponse, error) {
-	ret := _m.Called(email, password)
+// List provides a mock function with given fields: ctx, query
+func (_m *MockUsersService) List(ctx context.Context, query domain.ListUsersQuery) (*domain.UsersPage, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *domain.UsersPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ListUsersQuery) (*domain.UsersPage, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ListUsersQuery) *domain.UsersPage); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UsersPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ListUsersQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUsersService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockUsersService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query domain.ListUsersQuery
+func (_e *MockUsersService_Expecter) List(ctx interface{}, query interface{}) *MockUsersService_List_Call {
+	return &MockUsersService_List_Call{Call: _e.mock.On("List", ctx, query)}
+}
+
+func (_c *MockUsersService_List_Call) Run(run func(ctx context.Context, query domain.ListUsersQuery)) *MockUsersService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ListUsersQuery))
+	})
+	return _c
+}
+
+func (_c *MockUsersService_List_Call) Return(_a0 *domain.UsersPage, _a1 error) *MockUsersService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUsersService_List_Call) RunAndReturn(run func(context.Context, domain.ListUsersQuery) (*domain.UsersPage, error)) *MockUsersService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Login provides a mock function with given fields: ctx, email, password
+func (_m *MockUsersService) Login(ctx context.Context, email string, password string) (*domain.LoginResponse, error) {
+	ret := _m.Called(ctx, email, password)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
@@ -30,19 +91,19 @@ func (_m *MockUsersService) Login(email string, password string) (*domain.LoginR
 
 	var r0 *domain.LoginResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (*domain.LoginResponse, error)); ok {
-		return rf(email, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.LoginResponse, error)); ok {
+		return rf(ctx, email, password)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) *domain.LoginResponse); ok {
-		r0 = rf(email, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.LoginResponse); ok {
+		r0 = rf(ctx, email, password)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.LoginResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(email, password)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, password)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -56,15 +117,16 @@ type MockUsersService_Login_Call struct {
 }
 
 // Login is a helper method to define mock.On call
+//   - ctx context.Context
 //   - email string
 //   - password string
-func (_e *MockUsersService_Expecter) Login(email interface{}, password interface{}) *MockUsersService_Login_Call {
-	return &MockUsersService_Login_Call{Call: _e.mock.On("Login", email, password)}
+func (_e *MockUsersService_Expecter) Login(ctx interface{}, email interface{}, password interface{}) *MockUsersService_Login_Call {
+	return &MockUsersService_Login_Call{Call: _e.mock.On("Login", ctx, email, password)}
 }
 
-func (_c *MockUsersService_Login_Call) Run(run func(email string, password string)) *MockUsersService_Login_Call {
+func (_c *MockUsersService_Login_Call) Run(run func(ctx context.Context, email string, password string)) *MockUsersService_Login_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -74,14 +136,72 @@ func (_c *MockUsersService_Login_Call) Return(_a0 *domain.LoginResponse, _a1 err
 	return _c
 }
 
-func (_c *MockUsersService_Login_Call) RunAndReturn(run func(string, string) (*domain.LoginResponse, error)) *MockUsersService_Login_Call {
+func (_c *MockUsersService_Login_Call) RunAndReturn(run func(context.Context, string, string) (*domain.LoginResponse, error)) *MockUsersService_Login_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Me provides a mock function with given fields: ctx
+func (_m *MockUsersService) Me(ctx context.Context) (*domain.UserProfile, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Me")
+	}
+
+	var r0 *domain.UserProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*domain.UserProfile, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.UserProfile); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUsersService_Me_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Me'
+type MockUsersService_Me_Call struct {
+	*mock.Call
+}
+
+// Me is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUsersService_Expecter) Me(ctx interface{}) *MockUsersService_Me_Call {
+	return &MockUsersService_Me_Call{Call: _e.mock.On("Me", ctx)}
+}
+
+func (_c *MockUsersService_Me_Call) Run(run func(ctx context.Context)) *MockUsersService_Me_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockUsersService_Me_Call) Return(_a0 *domain.UserProfile, _a1 error) *MockUsersService_Me_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUsersService_Me_Call) RunAndReturn(run func(context.Context) (*domain.UserProfile, error)) *MockUsersService_Me_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Signup provides a mock function with given fields: payload
-func (_m *MockUsersService) Signup(payload *domain.SignupPayload) (*domain.SignupResponse, error) {
-	ret := _m.Called(payload)
+// Signup provides a mock function with given fields: ctx, payload
+func (_m *MockUsersService) Signup(ctx context.Context, payload *domain.SignupPayload) (*domain.SignupResponse, error) {
+	ret := _m.Called(ctx, payload)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Signup")
@@ -89,19 +209,19 @@ func (_m *MockUsersService) Signup(payload *domain.SignupPayload) (*domain.Signu
 
 	var r0 *domain.SignupResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*domain.SignupPayload) (*domain.SignupResponse, error)); ok {
-		return rf(payload)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.SignupPayload) (*domain.SignupResponse, error)); ok {
+		return rf(ctx, payload)
 	}
-	if rf, ok := ret.Get(0).(func(*domain.SignupPayload) *domain.SignupResponse); ok {
-		r0 = rf(payload)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.SignupPayload) *domain.SignupResponse); ok {
+		r0 = rf(ctx, payload)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.SignupResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*domain.SignupPayload) error); ok {
-		r1 = rf(payload)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.SignupPayload) error); ok {
+		r1 = rf(ctx, payload)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -115,14 +235,15 @@ type MockUsersService_Signup_Call struct {
 }
 
 // Signup is a helper method to define mock.On call
+//   - ctx context.Context
 //   - payload *domain.SignupPayload
-func (_e *MockUsersService_Expecter) Signup(payload interface{}) *MockUsersService_Signup_Call {
-	return &MockUsersService_Signup_Call{Call: _e.mock.On("Signup", payload)}
+func (_e *MockUsersService_Expecter) Signup(ctx interface{}, payload interface{}) *MockUsersService_Signup_Call {
+	return &MockUsersService_Signup_Call{Call: _e.mock.On("Signup", ctx, payload)}
 }
 
-func (_c *MockUsersService_Signup_Call) Run(run func(payload *domain.SignupPayload)) *MockUsersService_Signup_Call {
+func (_c *MockUsersService_Signup_Call) Run(run func(ctx context.Context, payload *domain.SignupPayload)) *MockUsersService_Signup_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*domain.SignupPayload))
+		run(args[0].(context.Context), args[1].(*domain.SignupPayload))
 	})
 	return _c
 }
@@ -132,7 +253,7 @@ func (_c *MockUsersService_Signup_Call) Return(_a0 *domain.SignupResponse, _a1 e
 	return _c
 }
 
-func (_c *MockUsersService_Signup_Call) RunAndReturn(run func(*domain.SignupPayload) (*domain.SignupResponse, error)) *MockUsersService_Signup_Call {
+func (_c *MockUsersService_Signup_Call) RunAndReturn(run func(context.Context, *domain.SignupPayload) (*domain.SignupResponse, error)) *MockUsersService_Signup_Call {
 	_c.Call.Return(run)
 	return _c
 }